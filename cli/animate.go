@@ -0,0 +1,89 @@
+package main
+
+import (
+	"algos/cluster"
+	"algos/drawer"
+	"algos/preprocess"
+	"fmt"
+	"slices"
+)
+
+// maxFrames limits the frames of an animation, DBSCAN reports a step per point.
+const maxFrames = 200
+
+// step is a snapshot of the labels, with the centroids for k-means.
+type step struct {
+	title     string
+	labels    []int
+	centroids []cluster.Point
+}
+
+// animation records the steps of a clusterer for -animate.
+type animation struct {
+	path  string
+	delay int
+	steps []step
+}
+
+// observe returns the clusterer with an observer recording its steps.
+func (a *animation) observe(c cluster.Clusterer) (cluster.Clusterer, error) {
+	switch c := c.(type) {
+	case cluster.DBSCANClusterer:
+		c.Observe = func(labels []int) {
+			k, _ := cluster.Count(labels)
+			a.steps = append(a.steps, step{title: fmt.Sprintf("DBSCAN: cluster %d", k), labels: slices.Clone(labels)})
+		}
+		a.delay = 20
+		return c, nil
+	case cluster.KMeansClusterer:
+		c.Observe = func(iter int, labels []int, centroids []cluster.Point) {
+			a.steps = append(a.steps, step{
+				title:     fmt.Sprintf("k-means: iteration %d", iter+1),
+				labels:    slices.Clone(labels),
+				centroids: slices.Clone(centroids),
+			})
+		}
+		a.delay = 100
+		return c, nil
+	}
+	return nil, fmt.Errorf("-animate works only with dbscan and k-means")
+}
+
+// save draws the recorded steps over the points in the original units,
+// scaler is nil when the points were clustered unscaled.
+func (a *animation) save(points []cluster.Point, scaler preprocess.Scaler, pf *plotFlags) error {
+	opts, err := pf.options()
+	if err != nil {
+		return err
+	}
+	steps := a.steps
+	if len(steps) > maxFrames {
+		// every n-th step and the last one
+		n := (len(steps) + maxFrames - 1) / maxFrames
+		var sampled []step
+		for i := 0; i < len(steps)-1; i += n {
+			sampled = append(sampled, steps[i])
+		}
+		steps = append(sampled, steps[len(steps)-1])
+	}
+
+	frames := make([]drawer.Frame, len(steps))
+	for i, s := range steps {
+		clusters, noise, err := cluster.Split(points, s.labels)
+		if err != nil {
+			return err
+		}
+		frames[i] = drawer.Frame{Title: s.title, Clusters: clusters, Noise: noise}
+		if s.centroids == nil {
+			continue
+		}
+		centroids := s.centroids
+		if scaler != nil {
+			if centroids, err = unscaleCentroids(scaler, s.centroids, points, s.labels); err != nil {
+				return err
+			}
+		}
+		frames[i].Centroids = cluster.XYs(centroids)
+	}
+	return drawer.SaveGIF(a.path, frames, a.delay, opts...)
+}
@@ -0,0 +1,443 @@
+package main
+
+import (
+	"algos/cluster"
+	"algos/preprocess"
+	"algos/tools"
+	"errors"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ioFlags are the input and output files shared by the subcommands.
+type ioFlags struct {
+	in, inFormat   string
+	out, outFormat string
+}
+
+func addIOFlags(fs *flag.FlagSet, input bool) *ioFlags {
+	f := &ioFlags{}
+	if input {
+		fs.StringVar(&f.in, "in", "-", "input points file, csv or json, - for stdin")
+		fs.StringVar(&f.inFormat, "in-format", "", "input format: csv, json (default from -in extension)")
+	}
+	fs.StringVar(&f.out, "out", "-", "output file, - for stdout")
+	fs.StringVar(&f.outFormat, "out-format", "", "output format: csv, json (default from -out extension)")
+	return f
+}
+
+// runClustering parses the common flags, clusters the input with the
+// clusterer made by setup and writes the result.
+func runClustering(name string, args []string, setup func(fs *flag.FlagSet) func() (cluster.Clusterer, error)) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	iof := addIOFlags(fs, true)
+	pf := addPlotFlags(fs, name)
+	modelPath := fs.String("save-model", "", "save the fitted model to this file, json by extension, binary otherwise")
+	scale := fs.String("scale", "none", "scale the points before clustering: none, zscore, minmax, robust, unit (unit can not be inverted, its centroids are the cluster means)")
+	outliers := fs.Float64("drop-outliers", 0, "drop points farther than this many standard deviations from the mean, 0 keeps all")
+	anim := &animation{}
+	fs.StringVar(&anim.path, "animate", "", "write an animated gif of the algorithm steps to this file (dbscan and k-means)")
+	newClusterer := setup(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	var scaler preprocess.Scaler
+	if *scale != "none" {
+		s, err := preprocess.New(*scale)
+		if err != nil {
+			return err
+		}
+		scaler = s
+	}
+
+	c, err := newClusterer()
+	if err != nil {
+		return err
+	}
+	ds, err := readDataset(iof.in, iof.inFormat)
+	if err != nil {
+		return err
+	}
+	if *outliers > 0 {
+		dropped := len(ds.Points)
+		ds.keep(preprocess.FilterOutliers(ds.Points, *outliers))
+		fmt.Fprintf(os.Stderr, "dropped %d outliers\n", dropped-len(ds.Points))
+	}
+	points := ds.Points
+	if scaler != nil {
+		if points, err = preprocess.FitPoints(scaler, ds.Points); err != nil {
+			return err
+		}
+	}
+	c = withEstimatedEps(c, points)
+	if anim.path != "" {
+		if c, err = anim.observe(c); err != nil {
+			return err
+		}
+	}
+	res, err := c.Fit(points)
+	if err != nil {
+		return err
+	}
+	// the output is in the original units
+	ds.Labels, ds.Centroids, ds.Exemplars = res.Labels, res.Centers(points), res.Exemplars
+	ds.core = corePoints(res, points)
+	if scaler != nil {
+		if ds.Centroids, err = unscaleCentroids(scaler, ds.Centroids, ds.Points, res.Labels); err != nil {
+			return err
+		}
+	}
+	metrics := res.Evaluate(ds.Points)
+	ds.Metrics = &metrics
+
+	clusters, noise := res.Count()
+	fmt.Fprintf(os.Stderr, "%s: %d clusters, %d noise points, inertia %g, silhouette %.3f\n",
+		res.Algorithm, clusters, noise, metrics.Inertia, metrics.Silhouette)
+	if v, ok := res.Meta["violations"].([]tools.Violation); ok && len(v) > 0 {
+		fmt.Fprintf(os.Stderr, "%s: unsatisfied constraints: %v\n", res.Algorithm, v)
+	}
+
+	if err := writeDataset(iof.out, iof.outFormat, ds); err != nil {
+		return err
+	}
+	if anim.path != "" {
+		if err := anim.save(ds.Points, scaler, pf); err != nil {
+			return err
+		}
+	}
+	if *modelPath != "" {
+		if res.Model == nil {
+			return fmt.Errorf("%s has no model to save", res.Algorithm)
+		}
+		// the model is in scaled units, predict scales the points with it
+		model := res.Model
+		if scaler != nil {
+			scaling, err := preprocess.PointScaling(scaler)
+			if err != nil {
+				return err
+			}
+			model = &cluster.ScaledModel{Model: model, Scaling: scaling}
+		}
+		if err := cluster.SaveModel(*modelPath, model); err != nil {
+			return err
+		}
+	}
+	return pf.render(ds)
+}
+
+// unscaleCentroids returns the centroids in the original units. Unit norm
+// scaling can not be inverted, its centroids are the weighted cluster means
+// of the original points instead.
+func unscaleCentroids(scaler preprocess.Scaler, centroids, points []cluster.Point, labels []int) ([]cluster.Point, error) {
+	if _, ok := scaler.(*preprocess.UnitNorm); ok {
+		return cluster.Centers(points, labels)
+	}
+	return preprocess.UnscalePoints(scaler, centroids)
+}
+
+// withEstimatedEps sets the radius of DBSCAN clusterers left at zero by
+// -eps to cluster.EstimateEps of the points.
+func withEstimatedEps(c cluster.Clusterer, points []cluster.Point) cluster.Clusterer {
+	switch cc := c.(type) {
+	case cluster.DBSCANClusterer:
+		if cc.Eps == 0 {
+			cc.Eps = cluster.EstimateEps(points)
+			fmt.Fprintf(os.Stderr, "estimated eps %g\n", cc.Eps)
+		}
+		return cc
+	case cluster.ConstrainedDBSCANClusterer:
+		if cc.Eps == 0 {
+			cc.Eps = cluster.EstimateEps(points)
+			fmt.Fprintf(os.Stderr, "estimated eps %g\n", cc.Eps)
+		}
+		return cc
+	}
+	return c
+}
+
+// corePoints marks the points that are core points of a DBSCAN model,
+// nil for the other algorithms.
+func corePoints(res *cluster.Result, points []cluster.Point) []bool {
+	m, ok := res.Model.(*cluster.DBSCANModel)
+	if !ok {
+		return nil
+	}
+	isCore := make(map[cluster.Point]bool, len(m.Core))
+	for _, p := range m.Core {
+		isCore[p] = true
+	}
+	core := make([]bool, len(points))
+	for i, p := range points {
+		core[i] = isCore[p]
+	}
+	return core
+}
+
+func runPredict(args []string) error {
+	fs := flag.NewFlagSet("predict", flag.ContinueOnError)
+	iof := addIOFlags(fs, true)
+	pf := addPlotFlags(fs, "predict")
+	modelPath := fs.String("model", "", "fitted model saved with -save-model (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *modelPath == "" {
+		return errors.New("-model is required")
+	}
+
+	m, err := cluster.LoadModel(*modelPath)
+	if err != nil {
+		return err
+	}
+	ds, err := readDataset(iof.in, iof.inFormat)
+	if err != nil {
+		return err
+	}
+	ds.Labels = cluster.PredictBatch(m, ds.Points, 0)
+	if ds.Centroids, err = modelCentroids(m); err != nil {
+		return err
+	}
+
+	if err := writeDataset(iof.out, iof.outFormat, ds); err != nil {
+		return err
+	}
+	return pf.render(ds)
+}
+
+// modelCentroids returns the centroids of a centroid model in the original
+// units, nil for the other models and for unit norm scaling, which can not
+// be inverted.
+func modelCentroids(m cluster.Model) ([]cluster.Point, error) {
+	switch m := m.(type) {
+	case *cluster.CentroidModel:
+		return m.Centroids, nil
+	case *cluster.ScaledModel:
+		cm, ok := m.Model.(*cluster.CentroidModel)
+		if !ok || m.Scaling.Unit {
+			return nil, nil
+		}
+		centroids := make([]cluster.Point, len(cm.Centroids))
+		for i, c := range cm.Centroids {
+			p, err := m.Scaling.Invert(c)
+			if err != nil {
+				return nil, err
+			}
+			centroids[i] = p
+		}
+		return centroids, nil
+	}
+	return nil, nil
+}
+
+func runDBSCAN(args []string) error {
+	return runClustering("dbscan", args, func(fs *flag.FlagSet) func() (cluster.Clusterer, error) {
+		eps := fs.Float64("eps", 0, "neighbourhood radius, estimated from the nearest neighbour distances if not set")
+		minPts := fs.Int("min-pts", 3, "minimum neighbourhood weight of a core point")
+		workers := fs.Int("workers", 0, "goroutines computing the distances, 0 for all cores")
+		return func() (cluster.Clusterer, error) {
+			return cluster.DBSCANClusterer{Eps: *eps, MinPts: *minPts, Workers: *workers}, nil
+		}
+	})
+}
+
+func runConstrainedDBSCAN(args []string) error {
+	return runClustering("constrained-dbscan", args, func(fs *flag.FlagSet) func() (cluster.Clusterer, error) {
+		eps := fs.Float64("eps", 0, "neighbourhood radius, estimated from the nearest neighbour distances if not set")
+		minPts := fs.Int("min-pts", 3, "minimum neighbourhood weight of a core point")
+		cons := addConstraintFlags(fs)
+		return func() (cluster.Clusterer, error) {
+			c, err := cons.parse()
+			if err != nil {
+				return nil, err
+			}
+			return cluster.ConstrainedDBSCANClusterer{Eps: *eps, MinPts: *minPts, Constraints: c}, nil
+		}
+	})
+}
+
+func runCOPKMeans(args []string) error {
+	return runClustering("cop-kmeans", args, func(fs *flag.FlagSet) func() (cluster.Clusterer, error) {
+		k := fs.Int("k", 4, "number of clusters")
+		cons := addConstraintFlags(fs)
+		return func() (cluster.Clusterer, error) {
+			c, err := cons.parse()
+			if err != nil {
+				return nil, err
+			}
+			return cluster.COPKMeansClusterer{K: *k, Constraints: c}, nil
+		}
+	})
+}
+
+// constraintFlags are the must-link and cannot-link pairs of point indices.
+type constraintFlags struct {
+	mustLink, cannotLink string
+}
+
+func addConstraintFlags(fs *flag.FlagSet) *constraintFlags {
+	f := &constraintFlags{}
+	fs.StringVar(&f.mustLink, "must-link", "", "pairs of point indices that must share a cluster, e.g. 0:5,7:9")
+	fs.StringVar(&f.cannotLink, "cannot-link", "", "pairs of point indices that must be in different clusters, e.g. 0:5,7:9")
+	return f
+}
+
+func (f *constraintFlags) parse() (*tools.Constraints, error) {
+	cons := tools.NewConstraints()
+	for _, c := range []struct {
+		flag, value string
+		add         func(a, b int)
+	}{
+		{"must-link", f.mustLink, cons.AddMustLink},
+		{"cannot-link", f.cannotLink, cons.AddCannotLink},
+	} {
+		if c.value == "" {
+			continue
+		}
+		for _, pair := range strings.Split(c.value, ",") {
+			a, b, found := strings.Cut(strings.TrimSpace(pair), ":")
+			i, errA := strconv.Atoi(a)
+			j, errB := strconv.Atoi(b)
+			if !found || errA != nil || errB != nil || i < 0 || j < 0 {
+				return nil, fmt.Errorf("-%s: %q is not a pair of point indices", c.flag, pair)
+			}
+			c.add(i, j)
+		}
+	}
+	return cons, nil
+}
+
+func runKMeans(name string, args []string, init cluster.Init) error {
+	return runClustering(name, args, func(fs *flag.FlagSet) func() (cluster.Clusterer, error) {
+		k := fs.Int("k", 4, "number of clusters")
+		return func() (cluster.Clusterer, error) {
+			return cluster.KMeansClusterer{K: *k, Init: init}, nil
+		}
+	})
+}
+
+func runMuesli(args []string) error {
+	return runClustering("muesli", args, func(fs *flag.FlagSet) func() (cluster.Clusterer, error) {
+		k := fs.Int("k", 4, "number of clusters")
+		return func() (cluster.Clusterer, error) {
+			return cluster.MuesliClusterer{K: *k}, nil
+		}
+	})
+}
+
+func runHierarchical(args []string) error {
+	return runClustering("hierarchical", args, func(fs *flag.FlagSet) func() (cluster.Clusterer, error) {
+		k := fs.Int("k", 4, "number of clusters")
+		linkage := fs.String("linkage", "average", "cluster distance: single, complete, average")
+		return func() (cluster.Clusterer, error) {
+			l, err := cluster.ParseLinkage(*linkage)
+			if err != nil {
+				return nil, err
+			}
+			return cluster.HierarchicalClusterer{K: *k, Linkage: l}, nil
+		}
+	})
+}
+
+func runSpectral(args []string) error {
+	return runClustering("spectral", args, func(fs *flag.FlagSet) func() (cluster.Clusterer, error) {
+		k := fs.Int("k", 4, "number of clusters")
+		affinity := fs.String("affinity", "knn", "similarity graph: rbf, knn")
+		sigma := fs.Float64("sigma", 0, "rbf kernel width, a local scale per point (the distance to its 7th neighbour) if not set")
+		neighbors := fs.Int("neighbors", 10, "neighbours per point of the knn graph")
+		return func() (cluster.Clusterer, error) {
+			a, err := cluster.ParseAffinity(*affinity)
+			if err != nil {
+				return nil, err
+			}
+			return cluster.SpectralClusterer{K: *k, Affinity: a, Sigma: *sigma, Neighbors: *neighbors}, nil
+		}
+	})
+}
+
+func runAffinity(args []string) error {
+	return runClustering("affinity", args, func(fs *flag.FlagSet) func() (cluster.Clusterer, error) {
+		preference := fs.Float64("preference", 0, "self similarity, larger values give more clusters, minus the squared median distance if not set")
+		damping := fs.Float64("damping", 0.5, "share of the previous message value, in [0.5, 1)")
+		return func() (cluster.Clusterer, error) {
+			c := cluster.AffinityPropagationClusterer{Damping: *damping}
+			fs.Visit(func(f *flag.Flag) {
+				if f.Name == "preference" {
+					c.Preference = preference
+				}
+			})
+			return c, nil
+		}
+	})
+}
+
+func runEvaluate(args []string) error {
+	fs := flag.NewFlagSet("evaluate", flag.ContinueOnError)
+	in := fs.String("in", "-", "labelled points file, csv or json, - for stdin")
+	inFormat := fs.String("in-format", "", "input format: csv, json (default from -in extension)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ds, err := readDataset(*in, *inFormat)
+	if err != nil {
+		return err
+	}
+	if ds.Labels == nil {
+		return errors.New("input has no labels")
+	}
+	metrics, err := cluster.Evaluate(ds.Points, ds.Labels)
+	if err != nil {
+		return err
+	}
+	clusters, noise := cluster.Count(ds.Labels)
+	fmt.Printf("points:     %d\n", len(ds.Points))
+	fmt.Printf("clusters:   %d\n", clusters)
+	fmt.Printf("noise:      %d\n", noise)
+	fmt.Printf("inertia:    %g\n", metrics.Inertia)
+	fmt.Printf("silhouette: %.4f\n", metrics.Silhouette)
+	return nil
+}
+
+func runPlot(args []string) error {
+	fs := flag.NewFlagSet("plot", flag.ContinueOnError)
+	in := fs.String("in", "-", "points file, csv or json, with optional labels, - for stdin")
+	inFormat := fs.String("in-format", "", "input format: csv, json (default from -in extension)")
+	pf := addPlotFlags(fs, "")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if pf.path == "" && pf.report == "" {
+		return errors.New("-plot or -report is required")
+	}
+
+	ds, err := readDataset(*in, *inFormat)
+	if err != nil {
+		return err
+	}
+	return pf.render(ds)
+}
+
+func runGenerate(args []string) error {
+	fs := flag.NewFlagSet("generate", flag.ContinueOnError)
+	iof := addIOFlags(fs, false)
+	n := fs.Int("n", 400, "number of points")
+	k := fs.Int("k", 3, "number of point clouds")
+	seed := fs.Int64("seed", 0, "random seed, 0 for the current time")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *n <= 0 || *k <= 0 {
+		return fmt.Errorf("-n and -k must be positive, got %d and %d", *n, *k)
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+
+	points := cluster.Generate(*n, *k, rand.New(rand.NewSource(*seed)))
+	return writeDataset(iof.out, iof.outFormat, &dataset{Points: points})
+}
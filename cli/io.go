@@ -0,0 +1,217 @@
+package main
+
+import (
+	"algos/cluster"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// dataset is the JSON form of points with optional labels and centroids.
+type dataset struct {
+	Points    []cluster.Point  `json:"points"`
+	Labels    []int            `json:"labels,omitempty"`
+	Centroids []cluster.Point  `json:"centroids,omitempty"`
+	Exemplars []int            `json:"exemplars,omitempty"`
+	Metrics   *cluster.Metrics `json:"metrics,omitempty"`
+
+	// core marks the core points of a DBSCAN clustering for the report.
+	core []bool
+}
+
+// keep leaves only the points with the given indices and their labels.
+func (ds *dataset) keep(indices []int) {
+	points := make([]cluster.Point, len(indices))
+	for i, k := range indices {
+		points[i] = ds.Points[k]
+	}
+	if ds.Labels != nil {
+		labels := make([]int, len(indices))
+		for i, k := range indices {
+			labels[i] = ds.Labels[k]
+		}
+		ds.Labels = labels
+	}
+	ds.Points = points
+}
+
+// dataFormat returns the format given by flag or by the file extension, csv by default.
+func dataFormat(format, path string) (string, error) {
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+	switch format {
+	case "", "csv":
+		return "csv", nil
+	case "json":
+		return "json", nil
+	}
+	return "", fmt.Errorf("unknown data format %q, want csv or json", format)
+}
+
+// readDataset reads points from path, "-" is stdin. CSV rows are
+// x,y[,w[,label]]; a first row that is not numeric is taken for a header.
+// Labels number the clusters from 1 with 0 for noise, negative labels such
+// as the -1 noise of other tools are rejected.
+// JSON is either an array of points or a dataset object.
+func readDataset(path, format string) (*dataset, error) {
+	format, err := dataFormat(format, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not open %s: %v", path, err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var ds *dataset
+	if format == "json" {
+		ds, err = decodeJSON(r)
+	} else {
+		ds, err = decodeCSV(r)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s: %v", path, err)
+	}
+	if len(ds.Points) == 0 {
+		return nil, fmt.Errorf("%s has no points", path)
+	}
+	if ds.Labels != nil && len(ds.Labels) != len(ds.Points) {
+		return nil, fmt.Errorf("%s has %d labels for %d points", path, len(ds.Labels), len(ds.Points))
+	}
+	if err := cluster.CheckLabels(ds.Labels); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return ds, nil
+}
+
+func decodeJSON(r io.Reader) (*dataset, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var ds dataset
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "[") {
+		err = json.Unmarshal(data, &ds.Points)
+	} else {
+		err = json.Unmarshal(data, &ds)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ds, nil
+}
+
+func decodeCSV(r io.Reader) (*dataset, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var ds dataset
+	for i, row := range rows {
+		if len(row) < 2 || len(row) > 4 {
+			return nil, fmt.Errorf("row %d: got %d fields, want 2 to 4", i+1, len(row))
+		}
+		var vals [3]float64
+		var parseErr error
+		for j := 0; j < len(row) && j < 3 && parseErr == nil; j++ {
+			vals[j], parseErr = strconv.ParseFloat(row[j], 64)
+		}
+		if parseErr != nil {
+			if i == 0 {
+				continue // header
+			}
+			return nil, fmt.Errorf("row %d: %v", i+1, parseErr)
+		}
+		ds.Points = append(ds.Points, cluster.Point{X: vals[0], Y: vals[1], W: vals[2]})
+		if len(row) == 4 {
+			label, err := strconv.Atoi(row[3])
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %v", i+1, err)
+			}
+			ds.Labels = append(ds.Labels, label)
+		}
+	}
+	if ds.Labels != nil && len(ds.Labels) != len(ds.Points) {
+		return nil, fmt.Errorf("labels are given for %d of %d points", len(ds.Labels), len(ds.Points))
+	}
+	return &ds, nil
+}
+
+// writeDataset writes the dataset to path, "-" is stdout.
+func writeDataset(path, format string, ds *dataset) error {
+	format, err := dataFormat(format, path)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	var f *os.File
+	if path != "-" {
+		if f, err = os.Create(path); err != nil {
+			return fmt.Errorf("could not create %s: %v", path, err)
+		}
+		w = f
+	}
+
+	if format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(ds)
+	} else {
+		err = encodeCSV(w, ds)
+	}
+	if err != nil {
+		if f != nil {
+			f.Close()
+		}
+		return fmt.Errorf("could not write to %s: %v", path, err)
+	}
+	if f != nil {
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("could not close %s: %v", path, err)
+		}
+	}
+	return nil
+}
+
+func encodeCSV(w io.Writer, ds *dataset) error {
+	cw := csv.NewWriter(w)
+	header := []string{"x", "y", "w"}
+	if ds.Labels != nil {
+		header = append(header, "label")
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for i, p := range ds.Points {
+		row := []string{fmtFloat(p.X), fmtFloat(p.Y), fmtFloat(p.Weight())}
+		if ds.Labels != nil {
+			row = append(row, strconv.Itoa(ds.Labels[i]))
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func fmtFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"algos/cluster"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+const usage = `usage: cli <command> [flags]
+
+commands:
+  dbscan        cluster points with DBSCAN
+  constrained-dbscan
+                cluster points with DBSCAN under must-link and cannot-link constraints
+  kmeans        cluster points with k-means, random initial centroids
+  kmeans++      cluster points with k-means, k-means++ initial centroids
+  cop-kmeans    cluster points with k-means under must-link and cannot-link constraints
+  muesli        cluster points with github.com/muesli/kmeans
+  hierarchical  cluster points by agglomerative clustering
+  spectral      cluster points by spectral clustering of a similarity graph
+  affinity      cluster points by affinity propagation, the number of clusters is found
+  predict       assign points to the clusters of a saved model
+  evaluate      print quality metrics of labelled points
+  plot          draw points or labelled points
+  generate      generate random point clouds
+  reduce        project multidimensional rows to 2D points by PCA or MDS
+
+Points are read from csv (x,y[,w[,label]]) or json files.
+Run "cli <command> -h" for the flags of a command.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch cmd, args := os.Args[1], os.Args[2:]; cmd {
+	case "dbscan":
+		err = runDBSCAN(args)
+	case "constrained-dbscan":
+		err = runConstrainedDBSCAN(args)
+	case "kmeans":
+		err = runKMeans(cmd, args, cluster.RandomInit)
+	case "kmeans++":
+		err = runKMeans(cmd, args, cluster.PlusPlusInit)
+	case "cop-kmeans":
+		err = runCOPKMeans(args)
+	case "muesli":
+		err = runMuesli(args)
+	case "hierarchical":
+		err = runHierarchical(args)
+	case "spectral":
+		err = runSpectral(args)
+	case "affinity":
+		err = runAffinity(args)
+	case "predict":
+		err = runPredict(args)
+	case "evaluate":
+		err = runEvaluate(args)
+	case "plot":
+		err = runPlot(args)
+	case "generate":
+		err = runGenerate(args)
+	case "reduce":
+		err = runReduce(args)
+	case "help", "-h", "-help", "--help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", cmd, usage)
+		os.Exit(2)
+	}
+
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"algos/cluster"
+	"algos/drawer"
+	"flag"
+	"fmt"
+	"slices"
+
+	"gonum.org/v1/plot/vg"
+)
+
+// plotFlags are the drawer rendering options shared by the subcommands.
+type plotFlags struct {
+	path    string
+	format  string
+	title   string
+	width   float64
+	height  float64
+	grid    bool
+	legend  bool
+	palette string
+	outline string
+	voronoi bool
+	report  string
+}
+
+func addPlotFlags(fs *flag.FlagSet, title string) *plotFlags {
+	pf := &plotFlags{}
+	fs.StringVar(&pf.path, "plot", "", "write a plot of the clusters to this file")
+	fs.StringVar(&pf.format, "plot-format", "", "plot format: png, svg, pdf, eps, jpg, tif (default from -plot extension)")
+	fs.StringVar(&pf.title, "title", title, "plot title")
+	fs.Float64Var(&pf.width, "width", 512, "plot width in points")
+	fs.Float64Var(&pf.height, "height", 512, "plot height in points")
+	fs.BoolVar(&pf.grid, "grid", false, "draw grid lines")
+	fs.BoolVar(&pf.legend, "legend", false, "draw a legend")
+	fs.StringVar(&pf.palette, "palette", drawer.Tableau10.Name, "colour palette: tableau10, set1, set3, okabe-ito")
+	fs.StringVar(&pf.outline, "outline", "none", "cluster outline: none, hull, ellipse")
+	fs.BoolVar(&pf.voronoi, "voronoi", false, "colour the background by the nearest centroid")
+	fs.StringVar(&pf.report, "report", "", "write an html report of the clusters to this file")
+	return pf
+}
+
+// options returns the drawer options shared by plots and animations.
+func (pf *plotFlags) options() ([]drawer.Option, error) {
+	palette, found := drawer.Palettes[pf.palette]
+	if !found {
+		return nil, fmt.Errorf("unknown palette %q", pf.palette)
+	}
+	var outline drawer.Outline
+	switch pf.outline {
+	case "none":
+		outline = drawer.NoOutline
+	case "hull":
+		outline = drawer.HullOutline
+	case "ellipse":
+		outline = drawer.EllipseOutline
+	default:
+		return nil, fmt.Errorf("unknown outline %q, want none, hull or ellipse", pf.outline)
+	}
+
+	opts := []drawer.Option{
+		drawer.WithTitle(pf.title),
+		drawer.WithSize(vg.Length(pf.width), vg.Length(pf.height)),
+		drawer.WithPalette(palette),
+		drawer.WithOutline(outline),
+	}
+	if pf.grid {
+		opts = append(opts, drawer.WithGrid())
+	}
+	if pf.legend {
+		opts = append(opts, drawer.WithLegend())
+	}
+	return opts, nil
+}
+
+// render draws the clusters when -plot is set and writes the report when
+// -report is set.
+func (pf *plotFlags) render(ds *dataset) error {
+	if pf.report != "" {
+		if err := pf.writeReport(ds); err != nil {
+			return err
+		}
+	}
+	if pf.path == "" {
+		return nil
+	}
+
+	opts, err := pf.options()
+	if err != nil {
+		return err
+	}
+	if pf.format != "" {
+		opts = append(opts, drawer.WithFormat(pf.format))
+	}
+	if len(ds.Exemplars) > 0 {
+		exemplars := make([]cluster.Point, len(ds.Exemplars))
+		for i, k := range ds.Exemplars {
+			if k < 0 || k >= len(ds.Points) {
+				return fmt.Errorf("exemplar %d is not a point index", k)
+			}
+			exemplars[i] = ds.Points[k]
+		}
+		opts = append(opts, drawer.WithExemplars(cluster.XYs(exemplars)))
+	}
+	if len(ds.Centroids) > 0 {
+		if len(ds.Exemplars) == 0 {
+			opts = append(opts, drawer.WithCentroids(cluster.XYs(ds.Centroids)))
+		}
+		if pf.voronoi {
+			opts = append(opts, drawer.WithVoronoi(cluster.XYs(ds.Centroids)))
+		}
+	}
+
+	if ds.Labels == nil {
+		return drawer.PlotData(pf.path, cluster.XYs(ds.Points), opts...)
+	}
+	clstrs, noise, err := cluster.Split(ds.Points, ds.Labels)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, drawer.WithNoise(noise))
+	if weights := clusterWeights(ds); weights != nil {
+		opts = append(opts, drawer.WithWeights(weights))
+	}
+	return drawer.PlotClasters(pf.path, clstrs, opts...)
+}
+
+// clusterWeights returns the point weights in the order of cluster.Split,
+// nil when no point has a weight.
+func clusterWeights(ds *dataset) [][]float64 {
+	if !slices.ContainsFunc(ds.Points, func(p cluster.Point) bool { return p.W != 0 }) {
+		return nil
+	}
+	k, _ := cluster.Count(ds.Labels)
+	weights := make([][]float64, k)
+	for i, p := range ds.Points {
+		if l := ds.Labels[i]; l != cluster.Noise {
+			weights[l-1] = append(weights[l-1], p.Weight())
+		}
+	}
+	return weights
+}
+
+// writeReport writes the html report. Point kinds are known only when the
+// points were clustered by DBSCAN.
+func (pf *plotFlags) writeReport(ds *dataset) error {
+	palette, found := drawer.Palettes[pf.palette]
+	if !found {
+		return fmt.Errorf("unknown palette %q", pf.palette)
+	}
+	points := make([]drawer.ReportPoint, len(ds.Points))
+	for i, p := range ds.Points {
+		points[i] = drawer.ReportPoint{ID: i, X: p.X, Y: p.Y}
+		if ds.Labels != nil {
+			points[i].Cluster = ds.Labels[i]
+		}
+		switch {
+		case ds.core == nil:
+		case ds.core[i]:
+			points[i].Kind = drawer.KindCore
+		case points[i].Cluster != cluster.Noise:
+			points[i].Kind = drawer.KindBorder
+		default:
+			points[i].Kind = drawer.KindNoise
+		}
+	}
+	return drawer.SaveReport(pf.report, drawer.Report{Title: pf.title, Points: points, Palette: palette})
+}
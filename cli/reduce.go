@@ -0,0 +1,103 @@
+package main
+
+import (
+	"algos/distmat"
+	"algos/preprocess"
+	"algos/reduce"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+)
+
+func runReduce(args []string) error {
+	fs := flag.NewFlagSet("reduce", flag.ContinueOnError)
+	in := fs.String("in", "-", "csv file with one numeric column per feature, - for stdin")
+	iof := addIOFlags(fs, false)
+	method := fs.String("method", "pca", "projection to 2D: pca, mds")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	rows, err := readRows(*in)
+	if err != nil {
+		return err
+	}
+	var projected [][]float64
+	switch *method {
+	case "pca":
+		pca, err := reduce.FitPCA(rows, 2)
+		if err != nil {
+			return err
+		}
+		if projected, err = pca.Transform(rows); err != nil {
+			return err
+		}
+		for i, r := range pca.Ratio {
+			fmt.Fprintf(os.Stderr, "component %d: variance %.4g, explained %.1f%%\n", i+1, pca.Variance[i], r*100)
+		}
+	case "mds":
+		dm := distmat.New[float64](len(rows), func(i, j int) float64 {
+			return euclidean(rows[i], rows[j])
+		}, 0)
+		var eigen []float64
+		if projected, eigen, err = reduce.MDS(dm, 2); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "mds eigenvalues: %.4g, %.4g\n", eigen[0], eigen[1])
+	default:
+		return fmt.Errorf("unknown method %q, want pca or mds", *method)
+	}
+	return writeDataset(iof.out, iof.outFormat, &dataset{Points: preprocess.Points(projected, nil)})
+}
+
+// readRows reads a numeric csv file, "-" is stdin. A first row that is not
+// numeric is taken for a header.
+func readRows(path string) ([][]float64, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not open %s: %v", path, err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s: %v", path, err)
+	}
+	var rows [][]float64
+	for i, record := range records {
+		row := make([]float64, len(record))
+		var parseErr error
+		for j := 0; j < len(record) && parseErr == nil; j++ {
+			row[j], parseErr = strconv.ParseFloat(record[j], 64)
+		}
+		if parseErr != nil {
+			if i == 0 {
+				continue // header
+			}
+			return nil, fmt.Errorf("could not read %s: row %d: %v", path, i+1, parseErr)
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%s has no rows", path)
+	}
+	return rows, nil
+}
+
+func euclidean(a, b []float64) float64 {
+	var sum float64
+	for i := range a {
+		sum += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return math.Sqrt(sum)
+}
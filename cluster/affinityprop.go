@@ -0,0 +1,218 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+
+	"algos/distmat"
+)
+
+const (
+	// apMaxIter ограничивает число итераций распространения сходства.
+	apMaxIter = 200
+	// apConvergenceIter - сколько итераций подряд набор образцов не должен
+	// меняться, чтобы считать, что алгоритм сошелся.
+	apConvergenceIter = 15
+	// apEps и apTiny - масштабы относительного и абсолютного шума сходств:
+	// машинный эпсилон и сто наименьших нормализованных float64.
+	apEps  = 0x1p-52
+	apTiny = 100 * 0x1p-1022
+)
+
+// AffinityPropagation - кластеризация распространением сходства (Frey,
+// Dueck): точки обмениваются сообщениями ответственности и доступности,
+// пока не выберут образцы - точки, представляющие кластеры. Число
+// кластеров не задается, его определяет preference - сходство точки с
+// самой собой: чем оно больше, тем больше кластеров.
+//
+// Сходство точек - минус квадрат расстояния из dm. При preference == nil
+// берется медиана сходств, то есть минус квадрат медианы расстояний.
+// damping - доля прежнего значения в каждом сообщении, от 0.5 до 1,
+// при damping == 0 берется 0.5. Точки на нулевом расстоянии друг от
+// друга обмениваются сообщениями как одна точка с весом, равным их
+// числу, и всегда попадают в один кластер: иначе сообщения дубликатов
+// колеблются между равными образцами.
+//
+// Возвращает метки точек с 1, индексы образцов по возрастанию (i-й -
+// образец кластера i+1) и признак сходимости: если за apMaxIter итераций
+// набор образцов не устоялся, результат последней итерации.
+func AffinityPropagation(dm *distmat.Matrix[float64], preference *float64, damping float64) (labels, exemplars []int, converged bool, err error) {
+	res, err := affinityPropagation(dm, preference, damping)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	return res.labels, res.exemplars, res.converged, nil
+}
+
+// apResult - результат affinityPropagation вместе с фактически
+// использованными параметрами и числом итераций.
+type apResult struct {
+	labels, exemplars   []int
+	preference, damping float64
+	iterations          int
+	converged           bool
+}
+
+func affinityPropagation(dm *distmat.Matrix[float64], preference *float64, damping float64) (*apResult, error) {
+	if dm.Len() == 0 {
+		return nil, errors.New("no points")
+	}
+	if damping == 0 {
+		damping = 0.5
+	}
+	if damping < 0.5 || damping >= 1 {
+		return nil, fmt.Errorf("damping must be in [0.5, 1), got %g", damping)
+	}
+	var pref float64
+	if preference != nil {
+		pref = *preference
+	} else {
+		median := dm.Median()
+		pref = -median * median
+	}
+	if math.IsNaN(pref) || math.IsInf(pref, 0) {
+		return nil, fmt.Errorf("preference must be finite, got %g", pref)
+	}
+	res := &apResult{preference: pref, damping: damping}
+
+	// unique - первые точки групп совпадающих точек, group - группа точки
+	var unique []int
+	group := make([]int, dm.Len())
+	for i := range group {
+		group[i] = len(unique)
+		for u, j := range unique {
+			if dm.At(i, j) == 0 {
+				group[i] = u
+				break
+			}
+		}
+		if group[i] == len(unique) {
+			unique = append(unique, i)
+		}
+	}
+	n := len(unique)
+	weight := make([]float64, n)
+	for _, u := range group {
+		weight[u]++
+	}
+
+	// матрица сходств с малым детерминированным шумом, как у scikit-learn:
+	// относительный шум разводит равные сходства, абсолютный - нулевые
+	rnd := rand.New(rand.NewSource(0))
+	s := make([][]float64, n)
+	for i := range s {
+		s[i] = make([]float64, n)
+		for k := range s[i] {
+			if i == k {
+				s[i][k] = pref
+			} else {
+				d := dm.At(unique[i], unique[k])
+				s[i][k] = -weight[i] * d * d
+			}
+			s[i][k] += (apEps*s[i][k] + apTiny) * rnd.NormFloat64()
+		}
+	}
+
+	r := make([][]float64, n)
+	a := make([][]float64, n)
+	for i := range r {
+		r[i] = make([]float64, n)
+		a[i] = make([]float64, n)
+	}
+	update := func(old *float64, v float64) {
+		*old = damping**old + (1-damping)*v
+	}
+
+	var (
+		exemplars = []int{0} // единственная точка - свой образец
+		stable    int
+		iter      int
+		converged bool
+	)
+	for iter = 1; iter <= apMaxIter && n > 1; iter++ {
+		// ответственность r(i,k): насколько k лучше других кандидатов для i
+		for i := 0; i < n; i++ {
+			first, second, best := math.Inf(-1), math.Inf(-1), -1
+			for k := 0; k < n; k++ {
+				v := a[i][k] + s[i][k]
+				if v > first {
+					first, second, best = v, first, k
+				} else if v > second {
+					second = v
+				}
+			}
+			for k := 0; k < n; k++ {
+				competitor := first
+				if k == best {
+					competitor = second
+				}
+				update(&r[i][k], s[i][k]-competitor)
+			}
+		}
+
+		// доступность a(i,k): насколько k подходит в образцы по мнению остальных
+		for k := 0; k < n; k++ {
+			var sum float64
+			for i := 0; i < n; i++ {
+				if i != k {
+					sum += math.Max(0, r[i][k])
+				}
+			}
+			for i := 0; i < n; i++ {
+				if i == k {
+					update(&a[k][k], sum)
+					continue
+				}
+				update(&a[i][k], math.Min(0, r[k][k]+sum-math.Max(0, r[i][k])))
+			}
+		}
+
+		var current []int
+		for k := 0; k < n; k++ {
+			if a[k][k]+r[k][k] > 0 {
+				current = append(current, k)
+			}
+		}
+		if len(current) > 0 && slices.Equal(current, exemplars) {
+			stable++
+		} else {
+			stable = 0
+		}
+		exemplars = current
+		if stable >= apConvergenceIter {
+			converged = true
+			break
+		}
+	}
+	res.iterations, res.converged = min(iter, apMaxIter), converged || n == 1
+	if len(exemplars) == 0 {
+		return nil, errors.New("no exemplars found, try a larger preference or damping")
+	}
+
+	// каждая точка относится к самому похожему образцу, образец - к себе
+	labels := make([]int, n)
+	for i := 0; i < n; i++ {
+		best := math.Inf(-1)
+		for c, k := range exemplars {
+			if i == k {
+				labels[i] = c + 1
+				break
+			}
+			if s[i][k] > best {
+				labels[i], best = c+1, s[i][k]
+			}
+		}
+	}
+	res.labels = make([]int, len(group))
+	for i, u := range group {
+		res.labels[i] = labels[u]
+	}
+	res.exemplars = make([]int, len(exemplars))
+	for c, k := range exemplars {
+		res.exemplars[c] = unique[k]
+	}
+	return res, nil
+}
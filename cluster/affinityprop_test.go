@@ -0,0 +1,92 @@
+package cluster
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func fitAffinity(t *testing.T, points []Point, preference *float64) *Result {
+	t.Helper()
+	res, err := AffinityPropagationClusterer{Preference: preference}.Fit(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+// TestAffinityPropagationDuplicates checks that copies of the points do not
+// change the clustering: the noise separates points with zero similarity.
+func TestAffinityPropagationDuplicates(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	var base []Point
+	for _, c := range []Point{{X: 0, Y: 0}, {X: 100, Y: 0}, {X: 0, Y: 100}} {
+		for i := 0; i < 4; i++ {
+			base = append(base, Point{X: c.X + rnd.Float64()*4, Y: c.Y + rnd.Float64()*4})
+		}
+	}
+	var points []Point
+	for i := 0; i < 3; i++ {
+		points = append(points, base...)
+	}
+
+	res := fitAffinity(t, points, nil)
+	if k, _ := res.Count(); k != 3 {
+		t.Errorf("got %d clusters, want 3", k)
+	}
+	if res.Meta["converged"] != true {
+		t.Error("did not converge")
+	}
+	for i := range base {
+		for c := 1; c < 3; c++ {
+			if j := i + c*len(base); res.Labels[j] != res.Labels[i] {
+				t.Errorf("copies %d and %d are in clusters %d and %d", i, j, res.Labels[i], res.Labels[j])
+			}
+		}
+	}
+}
+
+func TestAffinityPropagationRepeatedPoint(t *testing.T) {
+	points := []Point{{X: 0, Y: 0}, {X: 0, Y: 0}, {X: 0, Y: 0}, {X: 50, Y: 0}}
+	res := fitAffinity(t, points, nil)
+	if res.Labels[0] != res.Labels[1] || res.Labels[0] != res.Labels[2] {
+		t.Errorf("labels = %v, want the copies of (0,0) together", res.Labels)
+	}
+	// при preference -2500 один кластер с образцом (0,0) и два кластера
+	// одинаково хороши, образец (50,0) для копий хуже обоих
+	if len(res.Exemplars) == 0 || res.Exemplars[0] != 0 {
+		t.Errorf("exemplars = %v, want the first copy of (0,0) among them", res.Exemplars)
+	}
+}
+
+func TestAffinityPropagationIdenticalPoints(t *testing.T) {
+	points := make([]Point, 5)
+	for i := range points {
+		points[i] = Point{X: 3, Y: 3}
+	}
+	res := fitAffinity(t, points, nil)
+	if k, _ := res.Count(); k != 1 {
+		t.Errorf("got %d clusters, want 1", k)
+	}
+}
+
+func TestAffinityPropagationMeta(t *testing.T) {
+	points := []Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 3, Y: 0}}
+	res := fitAffinity(t, points, nil)
+	// медиана расстояний 2
+	if got := res.Meta["preference"]; got != -4.0 {
+		t.Errorf("Meta[preference] = %v, want -4", got)
+	}
+	if got := res.Meta["damping"]; got != 0.5 {
+		t.Errorf("Meta[damping] = %v, want 0.5", got)
+	}
+
+	zero := 0.0
+	res = fitAffinity(t, points, &zero)
+	if got := res.Meta["preference"]; got != 0.0 {
+		t.Errorf("Meta[preference] = %v, want 0", got)
+	}
+	// нулевое сходство с собой больше любого другого: каждая точка - образец
+	if k, _ := res.Count(); k != len(points) {
+		t.Errorf("preference 0: got %d clusters, want %d", k, len(points))
+	}
+}
@@ -0,0 +1,283 @@
+package cluster
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+
+	"algos/distmat"
+	"algos/tools"
+)
+
+// copMaxIter ограничивает число итераций COP-k-средних: с ограничениями
+// присвоение может колебаться, а не сходиться.
+const copMaxIter = 100
+
+// COPKMeans - k-средних с ограничениями (COP-k-means). Ограничения задаются
+// индексами точек в points: у Point нет собственного номера, поэтому
+// вызывающий переводит свои идентификаторы в индексы сам. Точки, связанные must-link, присваиваются вместе, и
+// точка никогда не попадает в кластер, где есть ее пара по cannot-link.
+// Если ни один кластер не подходит, группа уходит к ближайшему центроиду,
+// а нарушенные ограничения возвращаются.
+// Возвращает метки точек (с 1), центроиды и нарушения.
+func COPKMeans(points []Point, k int, cons *tools.Constraints) ([]int, []Point, []tools.Violation, error) {
+	if k <= 0 || k > len(points) {
+		return nil, nil, nil, fmt.Errorf("k must be in [1, %d], got %d", len(points), k)
+	}
+	if cons == nil {
+		cons = tools.NewConstraints()
+	}
+	groups := pointGroups(len(points), cons)
+
+	centroids := make([]Point, k)
+	for c, i := range rand.Perm(len(points))[:k] {
+		centroids[c] = Point{X: points[i].X, Y: points[i].Y}
+	}
+
+	var assign []int
+	for iter := 0; iter < copMaxIter; iter++ {
+		members := make([]*tools.Cluster, k)
+		for c := range members {
+			members[c] = tools.NewCluster(nil)
+		}
+
+		newAssign := make([]int, len(groups))
+		for g, group := range groups {
+			center := groupCenter(points, group)
+			order := make([]int, k)
+			for c := range order {
+				order[c] = c
+			}
+			slices.SortStableFunc(order, func(a, b int) int {
+				return cmp.Compare(center.Distance(centroids[a]), center.Distance(centroids[b]))
+			})
+
+			chosen := order[0]
+			for _, c := range order {
+				if canAddGroup(cons, members[c], group) {
+					chosen = c
+					break
+				}
+			}
+			for _, i := range group {
+				members[chosen].Points[i] = struct{}{}
+			}
+			newAssign[g] = chosen
+		}
+
+		// пустой кластер сохраняет прежний центроид
+		sums := make([]Point, k)
+		for g, group := range groups {
+			c := newAssign[g]
+			for _, i := range group {
+				w := points[i].Weight()
+				sums[c].X += points[i].X * w
+				sums[c].Y += points[i].Y * w
+				sums[c].W += w
+			}
+		}
+		for c, s := range sums {
+			if s.W > 0 {
+				centroids[c] = Point{X: s.X / s.W, Y: s.Y / s.W}
+			}
+		}
+
+		if slices.Equal(assign, newAssign) {
+			break
+		}
+		assign = newAssign
+	}
+
+	labels := make([]int, len(points))
+	for g, group := range groups {
+		for _, i := range group {
+			labels[i] = assign[g] + 1
+		}
+	}
+	return labels, centroids, cons.Check(labelMap(labels)), nil
+}
+
+// ConstrainedDBSCAN - DBSCAN, который никогда не объединяет точки,
+// связанные cannot-link. Ограничения задаются индексами точек в points,
+// как и в COPKMeans.
+// Как и в DBSCAN, кластер забирает только точки, еще не попавшие в другой
+// кластер, а точка, которую cannot-link не пускает в кластер, остается
+// для следующих. Затем кластеры и точки шума, связанные must-link,
+// объединяются, если это допускают cannot-link. Возвращает метки точек и
+// невыполненные ограничения.
+func ConstrainedDBSCAN(points []Point, eps float64, minPts int, cons *tools.Constraints) ([]int, []tools.Violation, error) {
+	if eps <= 0 {
+		return nil, nil, fmt.Errorf("eps must be positive, got %g", eps)
+	}
+	if minPts <= 0 {
+		return nil, nil, fmt.Errorf("minPts must be positive, got %d", minPts)
+	}
+	if cons == nil {
+		cons = tools.NewConstraints()
+	}
+
+	dm := distmat.New[float64](len(points), func(i, j int) float64 {
+		return points[i].Distance(points[j])
+	}, 0)
+	isCore := func(neighbors []int) bool {
+		var sum float64
+		for _, j := range neighbors {
+			sum += points[j].Weight()
+		}
+		return sum >= float64(minPts)
+	}
+
+	visited := make([]bool, len(points))
+	assigned := make([]bool, len(points))
+	var clusters []*tools.Cluster
+	for i := range points {
+		if visited[i] {
+			continue
+		}
+		visited[i] = true
+		neighbors := dm.Neighbors(i, eps)
+		if !isCore(neighbors) {
+			continue
+		}
+
+		c := tools.NewCluster([]int{i})
+		assigned[i] = true
+		for k := 0; k < len(neighbors); k++ {
+			j := neighbors[k]
+			if assigned[j] || !cons.CanAdd(c, j) {
+				continue
+			}
+			c.Points[j] = struct{}{}
+			assigned[j] = true
+			if visited[j] {
+				continue
+			}
+			visited[j] = true
+			if next := dm.Neighbors(j, eps); isCore(next) {
+				neighbors = append(neighbors, next...)
+			}
+		}
+		clusters = append(clusters, c)
+	}
+
+	clusters = mergeMustLinked(clusters, len(points), cons)
+
+	labels := make([]int, len(points))
+	for i, l := range tools.Labels(clusters) {
+		labels[i] = l
+	}
+	return labels, cons.Check(labelMap(labels)), nil
+}
+
+// mergeMustLinked объединяет кластеры и присоединяет точки шума, связанные
+// must-link, пока что-то меняется и cannot-link это допускает.
+func mergeMustLinked(clusters []*tools.Cluster, n int, cons *tools.Constraints) []*tools.Cluster {
+	for changed := true; changed; {
+		changed = false
+		for _, group := range cons.MustLinkGroups() {
+			for _, a := range group {
+				for _, b := range group {
+					if a >= b || a >= n || b >= n || a < 0 {
+						continue
+					}
+					ca, cb := clusterOf(clusters, a), clusterOf(clusters, b)
+					switch {
+					case ca < 0 && cb < 0, ca == cb:
+					case ca < 0:
+						if cons.CanAdd(clusters[cb], a) {
+							clusters[cb].Points[a] = struct{}{}
+							changed = true
+						}
+					case cb < 0:
+						if cons.CanAdd(clusters[ca], b) {
+							clusters[ca].Points[b] = struct{}{}
+							changed = true
+						}
+					default:
+						if cons.CanMerge(clusters[ca], clusters[cb]) {
+							clusters[ca].Merge(clusters[cb])
+							clusters = slices.Delete(clusters, cb, cb+1)
+							changed = true
+						}
+					}
+				}
+			}
+		}
+	}
+	return clusters
+}
+
+// clusterOf возвращает индекс первого кластера с точкой, -1 для шума.
+func clusterOf(clusters []*tools.Cluster, i int) int {
+	for c, cluster := range clusters {
+		if _, found := cluster.Points[i]; found {
+			return c
+		}
+	}
+	return -1
+}
+
+// pointGroups делит индексы точек на группы must-link, точки без
+// ограничений образуют группы из одной точки. Группы идут в порядке
+// своей первой точки, индексы вне [0, n) пропускаются.
+func pointGroups(n int, cons *tools.Constraints) [][]int {
+	groupOf := make(map[int]int)
+	mustLinked := cons.MustLinkGroups()
+	for g, group := range mustLinked {
+		for _, i := range group {
+			groupOf[i] = g
+		}
+	}
+
+	var groups [][]int
+	used := make(map[int]bool)
+	for i := 0; i < n; i++ {
+		g, found := groupOf[i]
+		if !found {
+			groups = append(groups, []int{i})
+			continue
+		}
+		if used[g] {
+			continue
+		}
+		used[g] = true
+		var group []int
+		for _, j := range mustLinked[g] {
+			if j >= 0 && j < n {
+				group = append(group, j)
+			}
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// groupCenter возвращает взвешенное среднее точек группы.
+func groupCenter(points []Point, group []int) Point {
+	var xSum, ySum, wSum float64
+	for _, i := range group {
+		xSum += points[i].X * points[i].Weight()
+		ySum += points[i].Y * points[i].Weight()
+		wSum += points[i].Weight()
+	}
+	return Point{X: xSum / wSum, Y: ySum / wSum}
+}
+
+func canAddGroup(cons *tools.Constraints, cluster *tools.Cluster, group []int) bool {
+	for _, i := range group {
+		if !cons.CanAdd(cluster, i) {
+			return false
+		}
+	}
+	return true
+}
+
+// labelMap переводит метки в вид, который принимает tools.Constraints.Check.
+func labelMap(labels []int) map[int]int {
+	result := make(map[int]int, len(labels))
+	for i, l := range labels {
+		result[i] = l
+	}
+	return result
+}
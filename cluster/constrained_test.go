@@ -0,0 +1,118 @@
+package cluster
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"algos/tools"
+)
+
+// TestConstrainedDBSCANSplitsAtCannotLink checks that clusters refused a merge
+// by cannot-link do not share points.
+func TestConstrainedDBSCANSplitsAtCannotLink(t *testing.T) {
+	cons := tools.NewConstraints()
+	cons.AddCannotLink(0, 10)
+
+	labels, violations, err := ConstrainedDBSCAN(line(0, 11), 1.5, 2, cons)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("violations = %v, want none", violations)
+	}
+	want := []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2}
+	if !slices.Equal(labels, want) {
+		t.Errorf("labels = %v, want %v", labels, want)
+	}
+}
+
+func TestConstrainedDBSCANMustLink(t *testing.T) {
+	cons := tools.NewConstraints()
+	cons.AddMustLink(0, 5)
+
+	points := append(line(0, 3), line(8, 11)...)
+	res, err := ConstrainedDBSCANClusterer{Eps: 1.5, MinPts: 2, Constraints: cons}.Fit(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := res.Meta["violations"].([]tools.Violation); len(v) != 0 {
+		t.Errorf("violations = %v, want none", v)
+	}
+	if len(res.Members) != 1 || len(res.Members[0]) != len(points) {
+		t.Errorf("members = %v, want one cluster with every point", res.Members)
+	}
+}
+
+// TestConstrainedDBSCANSharedBorder checks that a border point reachable
+// from two clusters does not join them.
+func TestConstrainedDBSCANSharedBorder(t *testing.T) {
+	var points []Point
+	for _, x := range []float64{0, .3, .6, .9, 2.4, 3.9, 4.2, 4.5, 4.8} {
+		points = append(points, Point{X: x})
+	}
+	want, err := DBSCAN(points, 1.5, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(want, []int{1, 1, 1, 1, 1, 2, 2, 2, 2}) {
+		t.Fatalf("DBSCAN labels = %v, the data should give two clusters", want)
+	}
+	got, _, err := ConstrainedDBSCAN(points, 1.5, 4, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("labels = %v, want %v", got, want)
+	}
+}
+
+func TestConstrainedDBSCANMatchesDBSCAN(t *testing.T) {
+	points := Generate(200, 3, rand.New(rand.NewSource(2)))
+	want, err := DBSCAN(points, 40, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k, _ := Count(want); k < 2 {
+		t.Fatalf("DBSCAN found %d clusters, the data should give several", k)
+	}
+	got, _, err := ConstrainedDBSCAN(points, 40, 4, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, want) {
+		t.Error("without constraints the labels differ from DBSCAN")
+	}
+}
+
+func TestCOPKMeans(t *testing.T) {
+	cons := tools.NewConstraints()
+	cons.AddCannotLink(0, 1)
+	cons.AddMustLink(1, 3)
+
+	points := append(line(0, 3), line(20, 23)...)
+	labels, centroids, violations, err := COPKMeans(points, 2, cons)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(centroids) != 2 {
+		t.Fatalf("got %d centroids, want 2", len(centroids))
+	}
+	if got := cons.Check(labelMap(labels)); !slices.Equal(got, violations) {
+		t.Errorf("reported violations %v, the labels break %v", violations, got)
+	}
+	if labels[1] != labels[3] {
+		t.Error("must-linked points 1 and 3 are apart")
+	}
+	if labels[0] == labels[1] {
+		t.Error("cannot-linked points 0 and 1 are together")
+	}
+}
+
+func TestCOPKMeansInvalidK(t *testing.T) {
+	for _, k := range []int{-1, 0, 4} {
+		if _, _, _, err := COPKMeans(line(0, 3), k, nil); err == nil {
+			t.Errorf("k=%d: want error", k)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package cluster
+
+import (
+	"fmt"
+
+	"algos/distmat"
+)
+
+// DBSCANObserver вызывается каждый раз, когда точка попадает в кластер, и
+// получает текущие метки точек: последний кластер - растущий. Срез меток
+// переиспользуется алгоритмом, его нужно копировать.
+type DBSCANObserver func(labels []int)
+
+// DBSCAN размечает точки алгоритмом DBSCAN. Точка считается ядровой, если
+// суммарный вес точек в ее eps-окрестности (включая ее саму) не меньше minPts.
+// Возвращает метки точек: кластеры нумеруются с 1, шум помечается Noise.
+func DBSCAN(points []Point, eps float64, minPts int) ([]int, error) {
+	labels, _, err := dbscan(points, eps, minPts, 0, nil)
+	return labels, err
+}
+
+// dbscan размечает точки и отмечает ядровые. Матрица расстояний
+// заполняется workers воркерами (workers <= 0 - все ядра), observe может
+// быть nil.
+func dbscan(points []Point, eps float64, minPts, workers int, observe DBSCANObserver) ([]int, []bool, error) {
+	if eps <= 0 {
+		return nil, nil, fmt.Errorf("eps must be positive, got %g", eps)
+	}
+	if minPts <= 0 {
+		return nil, nil, fmt.Errorf("minPts must be positive, got %d", minPts)
+	}
+
+	dm := distmat.New[float64](len(points), func(i, j int) float64 {
+		return points[i].Distance(points[j])
+	}, workers)
+	isCore := func(neighbors []int) bool {
+		var sum float64
+		for _, j := range neighbors {
+			sum += points[j].Weight()
+		}
+		return sum >= float64(minPts)
+	}
+
+	labels := make([]int, len(points))
+	core := make([]bool, len(points))
+	visited := make([]bool, len(points))
+	id := 0
+	for i := range points {
+		if visited[i] {
+			continue
+		}
+		visited[i] = true
+		neighbors := dm.Neighbors(i, eps)
+		if !isCore(neighbors) {
+			continue
+		}
+
+		id++
+		labels[i], core[i] = id, true
+		if observe != nil {
+			observe(labels)
+		}
+		// очередь растет, пока в нее добавляются окрестности ядровых точек
+		for k := 0; k < len(neighbors); k++ {
+			j := neighbors[k]
+			if labels[j] == Noise {
+				labels[j] = id
+				if observe != nil {
+					observe(labels)
+				}
+			}
+			if visited[j] {
+				continue
+			}
+			visited[j] = true
+			if next := dm.Neighbors(j, eps); isCore(next) {
+				core[j] = true
+				neighbors = append(neighbors, next...)
+			}
+		}
+	}
+	return labels, core, nil
+}
+
+// EstimateEps предлагает радиус окрестности для DBSCAN: утроенную
+// взвешенную медиану расстояний от точек до их ближайших соседок.
+// Для менее чем двух точек возвращает 0.
+func EstimateEps(points []Point) float64 {
+	if len(points) < 2 {
+		return 0
+	}
+	dm := distmat.New[float64](len(points), func(i, j int) float64 {
+		return points[i].Distance(points[j])
+	}, 0)
+	weights := make([]float64, len(points))
+	for i, p := range points {
+		weights[i] = p.Weight()
+	}
+	return 3 * distmat.WeightedMedian(dm.RowMin(), weights)
+}
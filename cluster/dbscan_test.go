@@ -0,0 +1,93 @@
+package cluster
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestDBSCANWorkers(t *testing.T) {
+	points := Generate(300, 3, rand.New(rand.NewSource(1)))
+	want, err := DBSCAN(points, 40, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range []int{1, 2, 3, 0} {
+		res, err := DBSCANClusterer{Eps: 40, MinPts: 4, Workers: w}.Fit(points)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(res.Labels, want) {
+			t.Errorf("workers=%d: labels differ from the default run", w)
+		}
+	}
+}
+
+func TestDBSCANObserver(t *testing.T) {
+	points := append(line(0, 3), line(10, 13)...)
+	points = append(points, Point{X: 50})
+	var steps [][]int
+	res, err := DBSCANClusterer{Eps: 1.5, MinPts: 2, Observe: func(labels []int) {
+		steps = append(steps, slices.Clone(labels))
+	}}.Fit(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// every clustered point is reported once, the last step is the result
+	if len(steps) != 6 {
+		t.Fatalf("got %d steps, want 6", len(steps))
+	}
+	if !slices.Equal(steps[len(steps)-1], res.Labels) {
+		t.Errorf("last step %v, labels %v", steps[len(steps)-1], res.Labels)
+	}
+	if steps[0][0] != 1 || steps[0][1] != Noise {
+		t.Errorf("first step = %v, want only the first point labelled", steps[0])
+	}
+}
+
+func TestEstimateEps(t *testing.T) {
+	if got := EstimateEps(line(0, 1)); got != 0 {
+		t.Errorf("EstimateEps of one point = %g, want 0", got)
+	}
+	if got := EstimateEps(line(0, 5)); got != 3 {
+		t.Errorf("EstimateEps = %g, want 3", got)
+	}
+}
+
+func TestKMeansObserver(t *testing.T) {
+	points := append(line(0, 3), line(20, 23)...)
+	iters := 0
+	res, err := KMeansClusterer{K: 2, Init: PlusPlusInit, Observe: func(iter int, labels []int, centroids []Point) {
+		if iter != iters {
+			t.Errorf("iteration %d reported as %d", iters, iter)
+		}
+		if len(labels) != len(points) || len(centroids) != 2 {
+			t.Errorf("got %d labels and %d centroids", len(labels), len(centroids))
+		}
+		iters++
+	}}.Fit(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if iters == 0 {
+		t.Fatal("observer was not called")
+	}
+	if res.Labels[0] == res.Labels[5] {
+		t.Errorf("labels = %v, want the two groups apart", res.Labels)
+	}
+}
+
+func BenchmarkDBSCAN(b *testing.B) {
+	points := Generate(2000, 3, rand.New(rand.NewSource(1)))
+	for _, w := range []int{1, 2, 4, 0} {
+		b.Run(fmt.Sprintf("workers=%d", w), func(b *testing.B) {
+			c := DBSCANClusterer{Eps: 40, MinPts: 4, Workers: w}
+			for i := 0; i < b.N; i++ {
+				if _, err := c.Fit(points); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package cluster
+
+import "math/rand"
+
+// Generate создает n точек с целыми координатами в k квадратных облаках
+// внутри [0, 2000) x [0, 2000): сторона облака от 500 до 1000, облако
+// сдвинуто по диагонали на случайную величину.
+func Generate(n, k int, rnd *rand.Rand) []Point {
+	points := make([]Point, 0, n)
+	for i := 0; i < k; i++ {
+		size := rnd.Intn(500) + 500
+		shift := rnd.Intn(2000 - size)
+		// остаток от деления достается последнему облаку
+		count := n / k
+		if i == k-1 {
+			count = n - len(points)
+		}
+		for j := 0; j < count; j++ {
+			points = append(points, Point{
+				X: float64(rnd.Intn(size) + shift),
+				Y: float64(rnd.Intn(size) + shift),
+			})
+		}
+	}
+	return points
+}
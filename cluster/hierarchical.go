@@ -0,0 +1,126 @@
+package cluster
+
+import (
+	"fmt"
+	"math"
+
+	"algos/distmat"
+)
+
+// Linkage - способ считать расстояние между кластерами.
+type Linkage int
+
+const (
+	// SingleLinkage - расстояние между ближайшими точками кластеров.
+	SingleLinkage Linkage = iota
+	// CompleteLinkage - расстояние между самыми дальними точками кластеров.
+	CompleteLinkage
+	// AverageLinkage - взвешенное среднее расстояние между точками кластеров.
+	AverageLinkage
+)
+
+func (l Linkage) String() string {
+	switch l {
+	case SingleLinkage:
+		return "single"
+	case CompleteLinkage:
+		return "complete"
+	case AverageLinkage:
+		return "average"
+	}
+	return fmt.Sprintf("Linkage(%d)", int(l))
+}
+
+// ParseLinkage разбирает название способа: single, complete или average.
+func ParseLinkage(s string) (Linkage, error) {
+	switch s {
+	case "single":
+		return SingleLinkage, nil
+	case "complete":
+		return CompleteLinkage, nil
+	case "average":
+		return AverageLinkage, nil
+	}
+	return 0, fmt.Errorf("unknown linkage %q, want single, complete or average", s)
+}
+
+// Hierarchical - агломеративная кластеризация: начиная с отдельных точек,
+// объединяет два ближайших кластера, пока их не останется k. Расстояния между
+// кластерами пересчитываются по формуле Ланса-Уильямса.
+// Метки нумеруются с 1 в порядке первой точки кластера.
+func Hierarchical(points []Point, k int, linkage Linkage) ([]int, error) {
+	n := len(points)
+	if k <= 0 || k > n {
+		return nil, fmt.Errorf("k must be in [1, %d], got %d", n, k)
+	}
+
+	// полная матрица расстояний между текущими кластерами, строки
+	// объединенных кластеров помечаются как неактивные
+	dm := distmat.New[float64](n, func(i, j int) float64 {
+		return points[i].Distance(points[j])
+	}, 0)
+	dist := make([][]float64, n)
+	for i := range dist {
+		dist[i] = make([]float64, n)
+		for j := range dist[i] {
+			dist[i][j] = dm.At(i, j)
+		}
+	}
+	active := make([]bool, n)
+	weight := make([]float64, n)
+	parent := make([]int, n)
+	for i, p := range points {
+		active[i], weight[i], parent[i] = true, p.Weight(), i
+	}
+
+	for clusters := n; clusters > k; clusters-- {
+		a, b, best := -1, -1, math.Inf(1)
+		for i := 0; i < n; i++ {
+			if !active[i] {
+				continue
+			}
+			for j := i + 1; j < n; j++ {
+				if active[j] && dist[i][j] < best {
+					a, b, best = i, j, dist[i][j]
+				}
+			}
+		}
+
+		// кластер b вливается в a
+		for j := 0; j < n; j++ {
+			if !active[j] || j == a || j == b {
+				continue
+			}
+			var d float64
+			switch linkage {
+			case SingleLinkage:
+				d = math.Min(dist[a][j], dist[b][j])
+			case CompleteLinkage:
+				d = math.Max(dist[a][j], dist[b][j])
+			default:
+				d = (weight[a]*dist[a][j] + weight[b]*dist[b][j]) / (weight[a] + weight[b])
+			}
+			dist[a][j], dist[j][a] = d, d
+		}
+		weight[a] += weight[b]
+		active[b] = false
+		parent[b] = a
+	}
+
+	root := func(i int) int {
+		for parent[i] != i {
+			i = parent[i]
+		}
+		return i
+	}
+	labels := make([]int, n)
+	ids := make(map[int]int)
+	for i := range points {
+		r := root(i)
+		if _, found := ids[r]; !found {
+			ids[r] = len(ids) + 1
+		}
+		labels[i] = ids[r]
+	}
+	return labels, nil
+}
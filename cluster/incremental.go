@@ -0,0 +1,407 @@
+package cluster
+
+import (
+	"fmt"
+	"sort"
+
+	"algos/tools"
+)
+
+// EventKind - что произошло с кластером после изменения набора точек.
+type EventKind int
+
+const (
+	ClusterCreated EventKind = iota + 1
+	ClusterMerged
+	ClusterSplit
+	ClusterRemoved
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case ClusterCreated:
+		return "created"
+	case ClusterMerged:
+		return "merged"
+	case ClusterSplit:
+		return "split"
+	case ClusterRemoved:
+		return "removed"
+	}
+	return fmt.Sprintf("EventKind(%d)", int(k))
+}
+
+// ChangeEvent описывает изменение структуры кластеров: Clusters - номера
+// получившихся кластеров, From - номера кластеров, из которых они получены.
+type ChangeEvent struct {
+	Kind     EventKind
+	Clusters []int
+	From     []int
+}
+
+func (e ChangeEvent) String() string {
+	return fmt.Sprintf("%s %v -> %v", e.Kind, e.From, e.Clusters)
+}
+
+// IncrementalDBSCAN поддерживает разбиение DBSCAN, пока точки добавляются
+// и удаляются по одной: пересчитываются только статусы и кластеры вокруг
+// измененной точки. Точки задаются номерами, которые выбирает вызывающий.
+// Точка ядровая, если суммарный вес ее eps-окрестности не меньше minPts.
+// Номера кластеров начинаются с 1 и не переиспользуются, 0 - шум.
+type IncrementalDBSCAN struct {
+	eps    float64
+	minPts int
+
+	points    map[int]Point
+	neighbors map[int]map[int]struct{} // eps-соседи точки без нее самой
+	labels    map[int]int
+	clusters  map[int]*tools.Cluster
+	nextID    int
+}
+
+// NewIncrementalDBSCAN создает пустое разбиение.
+func NewIncrementalDBSCAN(eps float64, minPts int) *IncrementalDBSCAN {
+	return &IncrementalDBSCAN{
+		eps:       eps,
+		minPts:    minPts,
+		points:    make(map[int]Point),
+		neighbors: make(map[int]map[int]struct{}),
+		labels:    make(map[int]int),
+		clusters:  make(map[int]*tools.Cluster),
+	}
+}
+
+// Insert добавляет точку с номером id и обновляет ядровые точки и кластеры вокруг нее.
+func (d *IncrementalDBSCAN) Insert(id int, p Point) ([]ChangeEvent, error) {
+	if _, ok := d.points[id]; ok {
+		return nil, fmt.Errorf("point %d already exists", id)
+	}
+
+	wasCore := make(map[int]bool)
+	nbs := make(map[int]struct{})
+	for n, q := range d.points {
+		if p.Distance(q) <= d.eps {
+			wasCore[n] = d.isCore(n)
+			nbs[n] = struct{}{}
+			d.neighbors[n][id] = struct{}{}
+		}
+	}
+	d.points[id] = p
+	d.neighbors[id] = nbs
+
+	var seeds []int
+	if d.isCore(id) {
+		seeds = append(seeds, id)
+	}
+	for n, was := range wasCore {
+		if !was && d.isCore(n) {
+			seeds = append(seeds, n)
+		}
+	}
+	sort.Ints(seeds)
+
+	events := d.connect(seeds)
+
+	d.assignBorder(id)
+	for _, s := range seeds {
+		for _, n := range sortedKeys(d.neighbors[s]) {
+			d.assignBorder(n)
+		}
+	}
+	return events, nil
+}
+
+// Delete удаляет точку с номером id, разделяя или удаляя кластеры,
+// которые потеряли связность.
+func (d *IncrementalDBSCAN) Delete(id int) ([]ChangeEvent, error) {
+	if _, ok := d.points[id]; !ok {
+		return nil, fmt.Errorf("point %d not found", id)
+	}
+
+	affected := make(map[int]struct{})
+	if d.isCore(id) {
+		affected[d.labels[id]] = struct{}{}
+	}
+	nbs := d.neighbors[id]
+	for q := range nbs {
+		before := d.isCore(q)
+		delete(d.neighbors[q], id)
+		if before && !d.isCore(q) {
+			affected[d.labels[q]] = struct{}{}
+		}
+	}
+	d.setLabel(id, 0)
+	delete(d.points, id)
+	delete(d.neighbors, id)
+
+	var events []ChangeEvent
+	for _, c := range sortedKeys(affected) {
+		events = append(events, d.split(c)...)
+	}
+	for _, q := range sortedKeys(nbs) {
+		d.assignBorder(q)
+	}
+	return events, nil
+}
+
+// Label возвращает номер кластера точки, 0 для шума, и false, если точки нет.
+func (d *IncrementalDBSCAN) Label(id int) (int, bool) {
+	if _, ok := d.points[id]; !ok {
+		return 0, false
+	}
+	return d.labels[id], true
+}
+
+// IsCore сообщает, является ли точка ядровой.
+func (d *IncrementalDBSCAN) IsCore(id int) bool {
+	if _, ok := d.points[id]; !ok {
+		return false
+	}
+	return d.isCore(id)
+}
+
+// Clusters возвращает текущие кластеры (номера точек) по возрастанию номера кластера.
+func (d *IncrementalDBSCAN) Clusters() []*tools.Cluster {
+	result := make([]*tools.Cluster, 0, len(d.clusters))
+	for _, c := range sortedKeys(d.clusters) {
+		result = append(result, d.clusters[c])
+	}
+	return result
+}
+
+// IDs возвращает номера всех точек по возрастанию.
+func (d *IncrementalDBSCAN) IDs() []int {
+	return sortedKeys(d.points)
+}
+
+// Result собирает текущее разбиение в Result: i-я точка результата - точка
+// с i-м по возрастанию номером, см. IDs. Кластеры перенумеровываются с 1
+// в порядке первой точки.
+func (d *IncrementalDBSCAN) Result() *Result {
+	ids := d.IDs()
+	points := make([]Point, len(ids))
+	labels := make([]int, len(ids))
+	core := make([]bool, len(ids))
+	renumber := make(map[int]int)
+	for i, id := range ids {
+		points[i], core[i] = d.points[id], d.isCore(id)
+		l := d.labels[id]
+		if l == Noise {
+			continue
+		}
+		if _, ok := renumber[l]; !ok {
+			renumber[l] = len(renumber) + 1
+		}
+		labels[i] = renumber[l]
+	}
+	r := newResult("incremental-dbscan", labels, nil)
+	r.Model = NewDBSCANModel(points, labels, core, d.eps, d.minPts)
+	r.Meta["eps"] = d.eps
+	r.Meta["minPts"] = d.minPts
+	return r
+}
+
+func (d *IncrementalDBSCAN) isCore(id int) bool {
+	sum := d.points[id].Weight()
+	for q := range d.neighbors[id] {
+		sum += d.points[q].Weight()
+	}
+	return sum >= float64(d.minPts)
+}
+
+// connect присоединяет новые ядровые точки к кластерам: связная группа
+// новых ядровых точек создает кластер, расширяет существующий или
+// объединяет несколько.
+func (d *IncrementalDBSCAN) connect(seeds []int) []ChangeEvent {
+	isSeed := make(map[int]bool, len(seeds))
+	for _, s := range seeds {
+		isSeed[s] = true
+	}
+
+	var events []ChangeEvent
+	visited := make(map[int]bool)
+	for _, s := range seeds {
+		if visited[s] {
+			continue
+		}
+		visited[s] = true
+
+		component := []int{s}
+		old := make(map[int]struct{})
+		for i := 0; i < len(component); i++ {
+			for _, q := range sortedKeys(d.neighbors[component[i]]) {
+				if !d.isCore(q) || visited[q] {
+					continue
+				}
+				if isSeed[q] {
+					visited[q] = true
+					component = append(component, q)
+					continue
+				}
+				old[d.labels[q]] = struct{}{}
+			}
+		}
+
+		ids := sortedKeys(old)
+		var target int
+		switch len(ids) {
+		case 0:
+			target = d.newCluster()
+			events = append(events, ChangeEvent{Kind: ClusterCreated, Clusters: []int{target}})
+		case 1:
+			target = ids[0]
+		default:
+			target = ids[0]
+			for _, id := range ids[1:] {
+				for n := range d.clusters[id].Points {
+					d.labels[n] = target
+				}
+				d.clusters[target].Merge(d.clusters[id])
+				delete(d.clusters, id)
+			}
+			events = append(events, ChangeEvent{Kind: ClusterMerged, Clusters: []int{target}, From: ids})
+		}
+
+		for _, c := range component {
+			d.setLabel(c, target)
+		}
+	}
+	return events
+}
+
+// split заново проверяет связность кластера, потерявшего ядровые точки.
+func (d *IncrementalDBSCAN) split(id int) []ChangeEvent {
+	members := d.clusters[id].GetPoints()
+
+	var components [][]int
+	visited := make(map[int]bool)
+	for _, m := range members {
+		if visited[m] || !d.isCore(m) {
+			continue
+		}
+		visited[m] = true
+		component := []int{m}
+		for i := 0; i < len(component); i++ {
+			for _, q := range sortedKeys(d.neighbors[component[i]]) {
+				if !visited[q] && d.isCore(q) {
+					visited[q] = true
+					component = append(component, q)
+				}
+			}
+		}
+		components = append(components, component)
+	}
+
+	if len(components) == 1 {
+		for _, m := range members {
+			d.assignBorder(m)
+		}
+		return nil
+	}
+
+	for _, m := range members {
+		d.setLabel(m, 0)
+	}
+	delete(d.clusters, id)
+
+	if len(components) == 0 {
+		for _, m := range members {
+			d.assignBorder(m)
+		}
+		return []ChangeEvent{{Kind: ClusterRemoved, From: []int{id}}}
+	}
+
+	ids := make([]int, 0, len(components))
+	for _, component := range components {
+		nid := d.newCluster()
+		for _, c := range component {
+			d.setLabel(c, nid)
+		}
+		ids = append(ids, nid)
+	}
+	for _, m := range members {
+		d.assignBorder(m)
+	}
+	return []ChangeEvent{{Kind: ClusterSplit, Clusters: ids, From: []int{id}}}
+}
+
+// assignBorder относит неядровую точку к кластеру соседней ядровой,
+// по возможности сохраняя текущий кластер, или помечает ее как шум.
+func (d *IncrementalDBSCAN) assignBorder(n int) {
+	if d.isCore(n) {
+		return
+	}
+	current := d.labels[n]
+	best := 0
+	for q := range d.neighbors[n] {
+		if !d.isCore(q) {
+			continue
+		}
+		l := d.labels[q]
+		if l == current {
+			return
+		}
+		if best == 0 || l < best {
+			best = l
+		}
+	}
+	d.setLabel(n, best)
+}
+
+func (d *IncrementalDBSCAN) setLabel(n, id int) {
+	if old := d.labels[n]; old != 0 && old != id {
+		delete(d.clusters[old].Points, n)
+	}
+	if id == 0 {
+		delete(d.labels, n)
+		return
+	}
+	d.clusters[id].Points[n] = struct{}{}
+	d.labels[n] = id
+}
+
+func (d *IncrementalDBSCAN) newCluster() int {
+	d.nextID++
+	d.clusters[d.nextID] = tools.NewCluster(nil)
+	return d.nextID
+}
+
+// IncrementalDBSCANClusterer добавляет точки в IncrementalDBSCAN по одной,
+// номер точки - ее индекс. Результат совпадает с DBSCAN с точностью до
+// пограничных точек, достижимых из нескольких кластеров; число событий
+// записывается в Meta["events"].
+type IncrementalDBSCANClusterer struct {
+	Eps    float64
+	MinPts int
+}
+
+// Fit реализует Clusterer.
+func (c IncrementalDBSCANClusterer) Fit(points []Point) (*Result, error) {
+	if c.Eps <= 0 {
+		return nil, fmt.Errorf("eps must be positive, got %g", c.Eps)
+	}
+	if c.MinPts <= 0 {
+		return nil, fmt.Errorf("minPts must be positive, got %d", c.MinPts)
+	}
+	d := NewIncrementalDBSCAN(c.Eps, c.MinPts)
+	events := 0
+	for i, p := range points {
+		e, err := d.Insert(i, p)
+		if err != nil {
+			return nil, err
+		}
+		events += len(e)
+	}
+	r := d.Result()
+	r.Meta["events"] = events
+	return r, nil
+}
+
+func sortedKeys[V any](m map[int]V) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
@@ -0,0 +1,205 @@
+package cluster
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// line returns points placed one unit apart on the x axis at x = n..m-1.
+func line(n, m int) []Point {
+	var points []Point
+	for i := n; i < m; i++ {
+		points = append(points, Point{X: float64(i), W: 1})
+	}
+	return points
+}
+
+// insertAll inserts the points with their x coordinate as id.
+func insertAll(t *testing.T, d *IncrementalDBSCAN, points []Point) []ChangeEvent {
+	t.Helper()
+	var events []ChangeEvent
+	for _, p := range points {
+		e, err := d.Insert(int(p.X), p)
+		if err != nil {
+			t.Fatalf("Insert(%g): %v", p.X, err)
+		}
+		events = append(events, e...)
+	}
+	return events
+}
+
+// membersOf returns the sorted ids of the points sharing the label of n.
+func membersOf(t *testing.T, d *IncrementalDBSCAN, n int) []int {
+	t.Helper()
+	l, ok := d.Label(n)
+	if !ok || l == 0 {
+		t.Fatalf("point %d is not clustered", n)
+	}
+	return d.clusters[l].GetPoints()
+}
+
+func TestIncrementalInsertCreates(t *testing.T) {
+	d := NewIncrementalDBSCAN(1.5, 2)
+	events := insertAll(t, d, line(0, 3))
+	if len(events) != 1 || events[0].Kind != ClusterCreated {
+		t.Fatalf("events = %v, want one created", events)
+	}
+	if got := membersOf(t, d, 0); !slices.Equal(got, []int{0, 1, 2}) {
+		t.Errorf("cluster = %v, want [0 1 2]", got)
+	}
+}
+
+func TestIncrementalInsertMerges(t *testing.T) {
+	d := NewIncrementalDBSCAN(1.5, 2)
+	insertAll(t, d, append(line(0, 2), line(3, 5)...))
+	if len(d.Clusters()) != 2 {
+		t.Fatalf("got %d clusters before the bridge, want 2", len(d.Clusters()))
+	}
+
+	events, err := d.Insert(2, Point{X: 2, W: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Kind != ClusterMerged || len(events[0].From) != 2 {
+		t.Fatalf("events = %v, want one merge of two clusters", events)
+	}
+	if len(d.Clusters()) != 1 {
+		t.Errorf("got %d clusters after the bridge, want 1", len(d.Clusters()))
+	}
+	if got := membersOf(t, d, 0); !slices.Equal(got, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("cluster = %v, want [0 1 2 3 4]", got)
+	}
+}
+
+func TestIncrementalDeleteSplits(t *testing.T) {
+	d := NewIncrementalDBSCAN(1.5, 2)
+	insertAll(t, d, line(0, 5))
+
+	events, err := d.Delete(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Kind != ClusterSplit || len(events[0].Clusters) != 2 {
+		t.Fatalf("events = %v, want one split into two clusters", events)
+	}
+	if got := membersOf(t, d, 0); !slices.Equal(got, []int{0, 1}) {
+		t.Errorf("left cluster = %v, want [0 1]", got)
+	}
+	if got := membersOf(t, d, 4); !slices.Equal(got, []int{3, 4}) {
+		t.Errorf("right cluster = %v, want [3 4]", got)
+	}
+	if _, ok := d.Label(2); ok {
+		t.Error("deleted point still has a label")
+	}
+}
+
+func TestIncrementalDeleteRemoves(t *testing.T) {
+	d := NewIncrementalDBSCAN(1.5, 2)
+	insertAll(t, d, line(0, 2))
+
+	events, err := d.Delete(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Kind != ClusterRemoved {
+		t.Fatalf("events = %v, want one removed", events)
+	}
+	if l, _ := d.Label(0); l != 0 {
+		t.Errorf("lonely point has label %d, want noise", l)
+	}
+	if len(d.Clusters()) != 0 {
+		t.Errorf("got %d clusters, want none", len(d.Clusters()))
+	}
+}
+
+func TestIncrementalErrors(t *testing.T) {
+	d := NewIncrementalDBSCAN(1.5, 2)
+	insertAll(t, d, line(0, 1))
+	if _, err := d.Insert(0, Point{}); err == nil {
+		t.Error("Insert of an existing id: want error")
+	}
+	if _, err := d.Delete(7); err == nil {
+		t.Error("Delete of an unknown id: want error")
+	}
+}
+
+// TestIncrementalMatchesDBSCAN checks that after random inserts and deletes
+// the core points are grouped exactly as a DBSCAN run from scratch groups them.
+func TestIncrementalMatchesDBSCAN(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	var points []Point
+	for i := 0; i < 150; i++ {
+		points = append(points, Point{X: rnd.Float64() * 100, Y: rnd.Float64() * 100, W: 1})
+	}
+	const eps, minPts = 8.0, 3
+
+	d := NewIncrementalDBSCAN(eps, minPts)
+	for i, p := range points {
+		if _, err := d.Insert(i, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var left []Point
+	var ids []int
+	for i, p := range points {
+		if i%4 == 0 {
+			if _, err := d.Delete(i); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		left = append(left, p)
+		ids = append(ids, i)
+	}
+
+	labels, err := DBSCAN(left, eps, minPts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := make(map[int]int)
+	got := make(map[int]int)
+	for i, id := range ids {
+		if d.IsCore(id) {
+			want[id] = labels[i]
+			got[id], _ = d.Label(id)
+		}
+	}
+	// the labellings must be the same partition up to renaming
+	mapping := make(map[int]int)
+	for n, l := range got {
+		if want[n] == Noise {
+			t.Fatalf("core point %d is noise for DBSCAN", n)
+		}
+		if m, ok := mapping[l]; ok && m != want[n] {
+			t.Fatalf("core point %d: cluster %d maps to two DBSCAN clusters", n, l)
+		}
+		mapping[l] = want[n]
+	}
+	if len(mapping) < 2 {
+		t.Fatalf("got %d clusters, the data should give several", len(mapping))
+	}
+	seen := make(map[int]bool)
+	for _, m := range mapping {
+		if seen[m] {
+			t.Fatal("two incremental clusters map to one DBSCAN cluster")
+		}
+		seen[m] = true
+	}
+}
+
+func TestIncrementalResult(t *testing.T) {
+	points := append(line(0, 3), line(10, 12)...)
+	points = append(points, Point{X: 50})
+	res, err := IncrementalDBSCANClusterer{Eps: 1.5, MinPts: 2}.Fit(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []int{1, 1, 1, 2, 2, Noise}
+	if !slices.Equal(res.Labels, want) {
+		t.Errorf("labels = %v, want %v", res.Labels, want)
+	}
+	if got := res.Predict(Point{X: 10.5}); got != 2 {
+		t.Errorf("Predict = %d, want 2", got)
+	}
+}
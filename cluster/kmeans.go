@@ -0,0 +1,122 @@
+package cluster
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/muesli/clusters"
+	"github.com/muesli/kmeans"
+)
+
+// kMeansMaxIter ограничивает число итераций, если центроиды не сходятся.
+const kMeansMaxIter = 300
+
+// KMeansObserver получает номер итерации k-средних (с 0), метки точек
+// после присвоения и центроиды, по которым они присвоены. Метки
+// переиспользуются на следующей итерации, их нужно копировать.
+type KMeansObserver func(iter int, labels []int, centroids []Point)
+
+// KMeans разбивает точки на k кластеров алгоритмом k-средних: начальные
+// центроиды выбираются среди точек случайно, центроиды пересчитываются как
+// взвешенное среднее точек кластера до сходимости.
+// Возвращает метки точек (с 1) и центроиды, i-й центроид - кластера i+1.
+func KMeans(points []Point, k int) ([]int, []Point, error) {
+	return kMeans(points, k, nil)
+}
+
+func kMeans(points []Point, k int, observe KMeansObserver) ([]int, []Point, error) {
+	if k <= 0 || k > len(points) {
+		return nil, nil, fmt.Errorf("k must be in [1, %d], got %d", len(points), k)
+	}
+
+	centroids := make([]Point, k)
+	for i := range centroids {
+		centroids[i] = points[rand.Intn(len(points))]
+	}
+	labels, centroids := lloyd(points, centroids, observe)
+	return labels, centroids, nil
+}
+
+// lloyd уточняет начальные центроиды итерациями Ллойда, observe может быть nil.
+func lloyd(points []Point, centroids []Point, observe KMeansObserver) ([]int, []Point) {
+	k := len(centroids)
+	labels := make([]int, len(points))
+	for iter := 0; iter < kMeansMaxIter; iter++ {
+		// присвоение точек к ближайшим центроидам
+		for i, p := range points {
+			labels[i] = nearest(p, centroids) + 1
+		}
+		if observe != nil {
+			observe(iter, labels, centroids)
+		}
+
+		// обновление центроидов, пустой кластер сохраняет прежний центроид
+		newCentroids := make([]Point, k)
+		wSum := make([]float64, k)
+		for i, p := range points {
+			c := labels[i] - 1
+			newCentroids[c].X += p.X * p.Weight()
+			newCentroids[c].Y += p.Y * p.Weight()
+			wSum[c] += p.Weight()
+		}
+		converged := true
+		for c := range newCentroids {
+			if wSum[c] == 0 {
+				newCentroids[c] = centroids[c]
+				continue
+			}
+			newCentroids[c] = Point{X: newCentroids[c].X / wSum[c], Y: newCentroids[c].Y / wSum[c]}
+			if newCentroids[c] != centroids[c] {
+				converged = false
+			}
+		}
+		centroids = newCentroids
+		if converged {
+			break
+		}
+	}
+	return labels, centroids
+}
+
+// nearest возвращает индекс ближайшего к p центроида.
+func nearest(p Point, centroids []Point) int {
+	closest, closestDist := -1, math.Inf(1)
+	for i, c := range centroids {
+		if d := p.Distance(c); d < closestDist {
+			closest, closestDist = i, d
+		}
+	}
+	return closest
+}
+
+// MuesliKMeans разбивает точки на k кластеров библиотекой muesli/kmeans.
+// Веса точек не учитываются. Результат в том же виде, что у KMeans.
+func MuesliKMeans(points []Point, k int) ([]int, []Point, error) {
+	if k <= 0 || k > len(points) {
+		return nil, nil, fmt.Errorf("k must be in [1, %d], got %d", len(points), k)
+	}
+
+	d := make(clusters.Observations, len(points))
+	for i, p := range points {
+		d[i] = clusters.Coordinates{p.X, p.Y}
+	}
+	km, err := kmeans.NewWithOptions(0.001, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	clstrs, err := km.Partition(d, k)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	labels := make([]int, len(points))
+	for i, o := range d {
+		labels[i] = clstrs.Nearest(o) + 1
+	}
+	centroids := make([]Point, len(clstrs))
+	for i, c := range clstrs {
+		centroids[i] = Point{X: c.Center[0], Y: c.Center[1]}
+	}
+	return labels, centroids, nil
+}
@@ -0,0 +1,57 @@
+package cluster
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// KMeansPP - k-средних с начальными центроидами k-means++: первый центроид
+// выбирается случайно с вероятностью, пропорциональной весу точки, каждый
+// следующий - с вероятностью, пропорциональной весу и квадрату расстояния
+// до ближайшего уже выбранного центроида.
+func KMeansPP(points []Point, k int) ([]int, []Point, error) {
+	return kMeansPP(points, k, nil)
+}
+
+func kMeansPP(points []Point, k int, observe KMeansObserver) ([]int, []Point, error) {
+	if k <= 0 || k > len(points) {
+		return nil, nil, fmt.Errorf("k must be in [1, %d], got %d", len(points), k)
+	}
+
+	centroids := make([]Point, 0, k)
+	scores := make([]float64, len(points))
+	for i, p := range points {
+		scores[i] = p.Weight()
+	}
+	for len(centroids) < k {
+		c := points[sample(scores)]
+		centroids = append(centroids, Point{X: c.X, Y: c.Y})
+		for i, p := range points {
+			d := p.Distance(centroids[nearest(p, centroids)])
+			scores[i] = p.Weight() * d * d
+		}
+	}
+
+	labels, centroids := lloyd(points, centroids, observe)
+	return labels, centroids, nil
+}
+
+// sample выбирает индекс с вероятностью, пропорциональной scores.
+// Если все значения нулевые, индекс выбирается равномерно.
+func sample(scores []float64) int {
+	var total float64
+	for _, s := range scores {
+		total += s
+	}
+	if total == 0 {
+		return rand.Intn(len(scores))
+	}
+	r := rand.Float64() * total
+	for i, s := range scores {
+		if r < s {
+			return i
+		}
+		r -= s
+	}
+	return len(scores) - 1
+}
@@ -0,0 +1,133 @@
+package cluster
+
+import "math"
+
+// Metrics - показатели качества разбиения.
+type Metrics struct {
+	// Inertia - взвешенная сумма квадратов расстояний от точек до центров их кластеров.
+	Inertia float64 `json:"inertia"`
+	// Silhouette - средний коэффициент силуэта точек кластеров, от -1 до 1.
+	Silhouette float64 `json:"silhouette"`
+}
+
+// Evaluate считает показатели качества разбиения, шум не учитывается.
+// Отрицательные метки дают ошибку.
+func Evaluate(points []Point, labels []int) (Metrics, error) {
+	if err := CheckLabels(labels); err != nil {
+		return Metrics{}, err
+	}
+	return evaluate(points, labels), nil
+}
+
+func evaluate(points []Point, labels []int) Metrics {
+	return Metrics{
+		Inertia:    Inertia(points, labels, centers(points, labels)),
+		Silhouette: silhouette(points, labels),
+	}
+}
+
+// Centers возвращает взвешенные центры кластеров, i-й центр - кластера i+1.
+// Отрицательные метки дают ошибку.
+func Centers(points []Point, labels []int) ([]Point, error) {
+	if err := CheckLabels(labels); err != nil {
+		return nil, err
+	}
+	return centers(points, labels), nil
+}
+
+func centers(points []Point, labels []int) []Point {
+	k, _ := Count(labels)
+	centers := make([]Point, k)
+	wSum := make([]float64, k)
+	for i, p := range points {
+		if labels[i] == Noise {
+			continue
+		}
+		c := labels[i] - 1
+		centers[c].X += p.X * p.Weight()
+		centers[c].Y += p.Y * p.Weight()
+		wSum[c] += p.Weight()
+	}
+	for c := range centers {
+		if wSum[c] > 0 {
+			centers[c] = Point{X: centers[c].X / wSum[c], Y: centers[c].Y / wSum[c]}
+		}
+	}
+	return centers
+}
+
+// Inertia возвращает взвешенную сумму квадратов расстояний от точек до
+// центров их кластеров, i-й центр - кластера i+1. Точки с метками без
+// центра, в том числе шум, не учитываются.
+func Inertia(points []Point, labels []int, centers []Point) float64 {
+	var sum float64
+	for i, p := range points {
+		if labels[i] < 1 || labels[i] > len(centers) {
+			continue
+		}
+		d := p.Distance(centers[labels[i]-1])
+		sum += p.Weight() * d * d
+	}
+	return sum
+}
+
+// Silhouette возвращает средний коэффициент силуэта точек кластеров.
+// Для точки s = (b - a) / max(a, b), где a - среднее расстояние до точек
+// своего кластера, b - минимальное среднее расстояние до точек другого.
+// Точки одиночных кластеров дают 0; при менее чем двух кластерах результат 0.
+// Отрицательные метки дают ошибку.
+func Silhouette(points []Point, labels []int) (float64, error) {
+	if err := CheckLabels(labels); err != nil {
+		return 0, err
+	}
+	return silhouette(points, labels), nil
+}
+
+func silhouette(points []Point, labels []int) float64 {
+	k, _ := Count(labels)
+	if k < 2 {
+		return 0
+	}
+
+	var total float64
+	var n int
+	sums := make([]float64, k)
+	counts := make([]int, k)
+	for i, p := range points {
+		if labels[i] == Noise {
+			continue
+		}
+		clear(sums)
+		clear(counts)
+		for j, q := range points {
+			if j == i || labels[j] == Noise {
+				continue
+			}
+			sums[labels[j]-1] += p.Distance(q)
+			counts[labels[j]-1]++
+		}
+
+		n++
+		own := labels[i] - 1
+		if counts[own] == 0 {
+			continue
+		}
+		a := sums[own] / float64(counts[own])
+		b := math.Inf(1)
+		for c := range sums {
+			if c != own && counts[c] > 0 {
+				b = math.Min(b, sums[c]/float64(counts[c]))
+			}
+		}
+		if math.IsInf(b, 1) {
+			continue
+		}
+		if s := math.Max(a, b); s > 0 {
+			total += (b - a) / s
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return total / float64(n)
+}
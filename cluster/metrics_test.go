@@ -0,0 +1,35 @@
+package cluster
+
+import "testing"
+
+func TestNegativeLabels(t *testing.T) {
+	points := []Point{{X: 0}, {X: 1}, {X: 10}}
+	labels := []int{1, -1, 2}
+	if _, _, err := Split(points, labels); err == nil {
+		t.Error("Split: want an error on label -1")
+	}
+	if _, err := Centers(points, labels); err == nil {
+		t.Error("Centers: want an error on label -1")
+	}
+	if _, err := Silhouette(points, labels); err == nil {
+		t.Error("Silhouette: want an error on label -1")
+	}
+	if _, err := Evaluate(points, labels); err == nil {
+		t.Error("Evaluate: want an error on label -1")
+	}
+	if _, err := NewResult("test", labels, nil); err == nil {
+		t.Error("NewResult: want an error on label -1")
+	}
+}
+
+func TestCenters(t *testing.T) {
+	points := []Point{{X: 0}, {X: 2, W: 3}, {X: 10}, {X: 50}}
+	centers, err := Centers(points, []int{1, 1, 2, Noise})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Point{{X: 1.5}, {X: 10}}
+	if len(centers) != len(want) || centers[0] != want[0] || centers[1] != want[1] {
+		t.Errorf("centers = %v, want %v", centers, want)
+	}
+}
@@ -0,0 +1,413 @@
+package cluster
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ModelVersion - версия формата сохраненных моделей. Версия 2 добавила
+// к модели центроидов алгоритм и число кластеров, версия 3 - масштабирование
+// точек ScaledModel.
+const ModelVersion = 3
+
+// Виды моделей в сохраненном файле.
+const (
+	centroidKind = "centroid"
+	dbscanKind   = "dbscan"
+)
+
+// binaryMagic открывает двоичный файл модели.
+var binaryMagic = [4]byte{'A', 'L', 'G', 'M'}
+
+// modelFile - сохраненная модель в формате JSON.
+type modelFile struct {
+	Version   int      `json:"version"`
+	Kind      string   `json:"kind"`
+	Algorithm string   `json:"algorithm,omitempty"`
+	K         int      `json:"k,omitempty"`
+	Centroids []Point  `json:"centroids,omitempty"`
+	Eps       float64  `json:"eps,omitempty"`
+	MinPts    int      `json:"minPts,omitempty"`
+	Core      []Point  `json:"core,omitempty"`
+	Labels    []int    `json:"labels,omitempty"`
+	Scaling   *Scaling `json:"scaling,omitempty"`
+}
+
+// Validate проверяет, что модель можно использовать для Predict.
+func (m *CentroidModel) Validate() error {
+	if len(m.Centroids) == 0 {
+		return errors.New("model has no centroids")
+	}
+	if m.K < 0 {
+		return fmt.Errorf("k must not be negative, got %d", m.K)
+	}
+	return validPoints(m.Centroids)
+}
+
+// Validate проверяет, что модель можно использовать для Predict.
+func (m *DBSCANModel) Validate() error {
+	if !(m.Eps > 0) || math.IsInf(m.Eps, 1) {
+		return fmt.Errorf("eps must be positive, got %g", m.Eps)
+	}
+	if m.MinPts <= 0 {
+		return fmt.Errorf("minPts must be positive, got %d", m.MinPts)
+	}
+	if len(m.Labels) != len(m.Core) {
+		return fmt.Errorf("got %d labels for %d core points", len(m.Labels), len(m.Core))
+	}
+	for i, l := range m.Labels {
+		if l <= Noise {
+			return fmt.Errorf("core point %d has label %d, want a cluster", i, l)
+		}
+	}
+	return validPoints(m.Core)
+}
+
+// Validate проверяет, что масштабирование можно применить к точкам.
+func (s *Scaling) Validate() error {
+	for j := range s.Scale {
+		if s.Scale[j] == 0 || math.IsNaN(s.Scale[j]) || math.IsInf(s.Scale[j], 0) {
+			return fmt.Errorf("scale must be finite and not zero, got %g", s.Scale[j])
+		}
+		if math.IsNaN(s.Shift[j]) || math.IsInf(s.Shift[j], 0) {
+			return fmt.Errorf("shift must be finite, got %g", s.Shift[j])
+		}
+	}
+	return nil
+}
+
+func validPoints(points []Point) error {
+	for i, p := range points {
+		for _, v := range []float64{p.X, p.Y, p.W} {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return fmt.Errorf("point %d has a non-finite coordinate or weight", i)
+			}
+		}
+	}
+	return nil
+}
+
+// toFile проверяет модель и переводит ее в сохраняемый вид. ScaledModel
+// сохраняется как вложенная модель с масштабированием.
+func toFile(m Model) (*modelFile, error) {
+	var scaling *Scaling
+	if sm, ok := m.(*ScaledModel); ok {
+		scaling, m = &sm.Scaling, sm.Model
+	}
+	var f *modelFile
+	switch m := m.(type) {
+	case *CentroidModel:
+		f = &modelFile{Version: ModelVersion, Kind: centroidKind, Algorithm: m.Algorithm, K: m.K, Centroids: m.Centroids}
+	case *DBSCANModel:
+		f = &modelFile{Version: ModelVersion, Kind: dbscanKind, Eps: m.Eps, MinPts: m.MinPts, Core: m.Core, Labels: m.Labels}
+	default:
+		return nil, fmt.Errorf("model %T can not be saved", m)
+	}
+	f.Scaling = scaling
+	if _, err := f.model(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// model проверяет сохраненную модель и восстанавливает ее.
+func (f *modelFile) model() (Model, error) {
+	if f.Version < 1 || f.Version > ModelVersion {
+		return nil, fmt.Errorf("unsupported model version %d, want 1 to %d", f.Version, ModelVersion)
+	}
+	var m interface {
+		Model
+		Validate() error
+	}
+	switch f.Kind {
+	case centroidKind:
+		m = &CentroidModel{Centroids: f.Centroids, Algorithm: f.Algorithm, K: f.K}
+	case dbscanKind:
+		m = &DBSCANModel{Eps: f.Eps, MinPts: f.MinPts, Core: f.Core, Labels: f.Labels}
+	default:
+		return nil, fmt.Errorf("unknown model kind %q", f.Kind)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s model: %v", f.Kind, err)
+	}
+	if f.Scaling == nil {
+		return m, nil
+	}
+	if err := f.Scaling.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s scaling: %v", f.Scaling.Name, err)
+	}
+	return &ScaledModel{Model: m, Scaling: *f.Scaling}, nil
+}
+
+// WriteModelJSON записывает модель в w в формате JSON.
+func WriteModelJSON(w io.Writer, m Model) error {
+	f, err := toFile(m)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(f)
+}
+
+// ReadModelJSON читает и проверяет модель в формате JSON.
+func ReadModelJSON(r io.Reader) (Model, error) {
+	var f modelFile
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&f); err != nil {
+		return nil, fmt.Errorf("could not decode model: %v", err)
+	}
+	return f.model()
+}
+
+// WriteModelBinary записывает модель в компактном двоичном формате:
+// сигнатура ALGM, версия, вид модели, данные в little endian и CRC32 всего
+// предыдущего содержимого. Строки записываются длиной uint16 и байтами.
+// Масштабирование идет после данных модели с признаком наличия uint8.
+func WriteModelBinary(w io.Writer, m Model) error {
+	f, err := toFile(m)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(binaryMagic[:])
+	put := func(v any) {
+		// запись в bytes.Buffer не возвращает ошибок
+		_ = binary.Write(&buf, binary.LittleEndian, v)
+	}
+	putPoints := func(points []Point) {
+		put(uint32(len(points)))
+		for _, p := range points {
+			put([3]float64{p.X, p.Y, p.W})
+		}
+	}
+	putString := func(s string) error {
+		if len(s) > math.MaxUint16 {
+			return fmt.Errorf("string is %d bytes long, at most %d fit", len(s), math.MaxUint16)
+		}
+		put(uint16(len(s)))
+		buf.WriteString(s)
+		return nil
+	}
+	put(uint16(f.Version))
+	switch f.Kind {
+	case centroidKind:
+		put(uint8(1))
+		if err := putString(f.Algorithm); err != nil {
+			return err
+		}
+		put(uint32(f.K))
+		putPoints(f.Centroids)
+	case dbscanKind:
+		put(uint8(2))
+		put(f.Eps)
+		put(uint32(f.MinPts))
+		putPoints(f.Core)
+		for _, l := range f.Labels {
+			put(uint32(l))
+		}
+	}
+	if f.Scaling == nil {
+		put(uint8(0))
+	} else {
+		put(uint8(1))
+		if err := putString(f.Scaling.Name); err != nil {
+			return err
+		}
+		put(f.Scaling.Shift)
+		put(f.Scaling.Scale)
+		put(f.Scaling.Unit)
+	}
+	put(crc32.ChecksumIEEE(buf.Bytes()))
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// ReadModelBinary читает и проверяет модель в двоичном формате.
+func ReadModelBinary(r io.Reader) (Model, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < len(binaryMagic)+4 || !bytes.Equal(data[:len(binaryMagic)], binaryMagic[:]) {
+		return nil, errors.New("not a binary model file")
+	}
+	body, sum := data[:len(data)-4], binary.LittleEndian.Uint32(data[len(data)-4:])
+	if crc32.ChecksumIEEE(body) != sum {
+		return nil, errors.New("model file checksum mismatch")
+	}
+
+	br := bytes.NewReader(body[len(binaryMagic):])
+	get := func(v any) error {
+		return binary.Read(br, binary.LittleEndian, v)
+	}
+	getPoints := func() ([]Point, error) {
+		var n uint32
+		if err := get(&n); err != nil {
+			return nil, err
+		}
+		if int64(n)*24 > int64(br.Len()) {
+			return nil, fmt.Errorf("model declares %d points, more than the file holds", n)
+		}
+		points := make([]Point, n)
+		for i := range points {
+			var v [3]float64
+			if err := get(&v); err != nil {
+				return nil, err
+			}
+			points[i] = Point{X: v[0], Y: v[1], W: v[2]}
+		}
+		return points, nil
+	}
+	getString := func() (string, error) {
+		var n uint16
+		if err := get(&n); err != nil {
+			return "", err
+		}
+		if int(n) > br.Len() {
+			return "", fmt.Errorf("model declares a %d byte string, more than the file holds", n)
+		}
+		b := make([]byte, n)
+		if _, err := io.ReadFull(br, b); err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+
+	var (
+		version uint16
+		kind    uint8
+		f       modelFile
+	)
+	if err := get(&version); err != nil {
+		return nil, fmt.Errorf("could not read model: %v", err)
+	}
+	if err := get(&kind); err != nil {
+		return nil, fmt.Errorf("could not read model: %v", err)
+	}
+	f.Version = int(version)
+	if f.Version < 1 || f.Version > ModelVersion {
+		return nil, fmt.Errorf("unsupported model version %d, want 1 to %d", f.Version, ModelVersion)
+	}
+	switch kind {
+	case 1:
+		f.Kind = centroidKind
+		if f.Version >= 2 {
+			var k uint32
+			if f.Algorithm, err = getString(); err == nil {
+				err = get(&k)
+			}
+			f.K = int(k)
+		}
+		if err == nil {
+			f.Centroids, err = getPoints()
+		}
+	case 2:
+		f.Kind = dbscanKind
+		var minPts uint32
+		if err = get(&f.Eps); err == nil {
+			err = get(&minPts)
+		}
+		if err == nil {
+			f.MinPts = int(minPts)
+			f.Core, err = getPoints()
+		}
+		if err == nil {
+			labels := make([]uint32, len(f.Core))
+			err = get(labels)
+			for _, l := range labels {
+				f.Labels = append(f.Labels, int(l))
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown model kind %d", kind)
+	}
+	if err == nil && f.Version >= 3 {
+		var scaled uint8
+		if err = get(&scaled); err == nil && scaled != 0 {
+			f.Scaling = &Scaling{}
+			if f.Scaling.Name, err = getString(); err == nil {
+				err = get(&f.Scaling.Shift)
+			}
+			if err == nil {
+				err = get(&f.Scaling.Scale)
+			}
+			if err == nil {
+				err = get(&f.Scaling.Unit)
+			}
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read model: %v", err)
+	}
+	if br.Len() != 0 {
+		return nil, fmt.Errorf("model file has %d trailing bytes", br.Len())
+	}
+	return f.model()
+}
+
+// SaveModel сохраняет модель в файл: в JSON для расширения .json,
+// иначе в двоичном формате.
+func SaveModel(path string, m Model) error {
+	if _, err := toFile(m); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create %s: %v", path, err)
+	}
+	w := bufio.NewWriter(file)
+	if isJSON(path) {
+		err = WriteModelJSON(w, m)
+	} else {
+		err = WriteModelBinary(w, m)
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("could not write to %s: %v", path, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("could not close %s: %v", path, err)
+	}
+	return nil
+}
+
+// LoadModel загружает и проверяет модель, формат выбирается как в SaveModel.
+func LoadModel(path string) (Model, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open %s: %v", path, err)
+	}
+	defer file.Close()
+
+	var m Model
+	if isJSON(path) {
+		m, err = ReadModelJSON(file)
+	} else {
+		m, err = ReadModelBinary(file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not load %s: %v", path, err)
+	}
+	return m, nil
+}
+
+func isJSON(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".json")
+}
@@ -0,0 +1,144 @@
+package cluster
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testModels() map[string]Model {
+	return map[string]Model{
+		"centroid": &CentroidModel{
+			Centroids: []Point{{X: 1, Y: 2}, {X: -3, Y: 4.5, W: 2}},
+			Algorithm: "kmeans++",
+			K:         2,
+		},
+		"dbscan": &DBSCANModel{
+			Eps:    1.5,
+			MinPts: 4,
+			Core:   []Point{{X: 0, Y: 0}, {X: 1, Y: 0, W: 3}, {X: 10, Y: 10}},
+			Labels: []int{1, 1, 2},
+		},
+		"scaled": &ScaledModel{
+			Model:   &CentroidModel{Centroids: []Point{{X: 0.5, Y: -1}}, Algorithm: "kmeans", K: 1},
+			Scaling: Scaling{Name: "zscore", Shift: [2]float64{10, 20}, Scale: [2]float64{2, 4}},
+		},
+	}
+}
+
+func TestScaledModelPredict(t *testing.T) {
+	m := &ScaledModel{
+		Model:   &CentroidModel{Centroids: []Point{{X: 0, Y: 0}, {X: 1, Y: 0}}},
+		Scaling: Scaling{Name: "minmax", Shift: [2]float64{100, 0}, Scale: [2]float64{10, 1}},
+	}
+	// в масштабе модели точки переходят в 0.2 и 0.8
+	if got := PredictBatch(m, []Point{{X: 102}, {X: 108}}, 1); got[0] != 1 || got[1] != 2 {
+		t.Errorf("labels = %v, want [1 2]", got)
+	}
+	if p, err := m.Scaling.Invert(Point{X: 0.5, Y: 2}); err != nil || p != (Point{X: 105, Y: 2}) {
+		t.Errorf("Invert = %v, %v, want {105 2}", p, err)
+	}
+	m.Scaling.Unit = true
+	if _, err := m.Scaling.Invert(Point{}); err == nil {
+		t.Error("Invert with unit norm: want error")
+	}
+}
+
+func TestModelRoundTrip(t *testing.T) {
+	for name, m := range testModels() {
+		var bin, js bytes.Buffer
+		if err := WriteModelBinary(&bin, m); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if err := WriteModelJSON(&js, m); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		got, err := ReadModelBinary(&bin)
+		if err != nil {
+			t.Errorf("%s: binary: %v", name, err)
+		} else if !reflect.DeepEqual(got, m) {
+			t.Errorf("%s: binary: got %+v, want %+v", name, got, m)
+		}
+		got, err = ReadModelJSON(&js)
+		if err != nil {
+			t.Errorf("%s: json: %v", name, err)
+		} else if !reflect.DeepEqual(got, m) {
+			t.Errorf("%s: json: got %+v, want %+v", name, got, m)
+		}
+	}
+}
+
+// withCRC дописывает к содержимому двоичного файла контрольную сумму.
+func withCRC(body []byte) []byte {
+	return binary.LittleEndian.AppendUint32(body, crc32.ChecksumIEEE(body))
+}
+
+// centroidFile собирает двоичный файл модели центроидов версии 1
+// с заявленным числом точек n и одной записанной точкой.
+func centroidFile(version uint16, n uint32) []byte {
+	b := append([]byte(nil), binaryMagic[:]...)
+	b = binary.LittleEndian.AppendUint16(b, version)
+	b = append(b, 1)
+	b = binary.LittleEndian.AppendUint32(b, n)
+	for _, v := range []float64{1, 2, 0} {
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(v))
+	}
+	return b
+}
+
+func TestReadModelBinaryVersion1(t *testing.T) {
+	m, err := ReadModelBinary(bytes.NewReader(withCRC(centroidFile(1, 1))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &CentroidModel{Centroids: []Point{{X: 1, Y: 2}}}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("got %+v, want %+v", m, want)
+	}
+}
+
+func TestReadModelBinaryInvalid(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteModelBinary(&buf, testModels()["dbscan"]); err != nil {
+		t.Fatal(err)
+	}
+	valid := buf.Bytes()
+	flipped := bytes.Clone(valid)
+	flipped[len(flipped)/2] ^= 1
+	body := valid[:len(valid)-4]
+	trailing := withCRC(append(bytes.Clone(body), 0, 0))
+
+	for name, tc := range map[string]struct {
+		data []byte
+		want string
+	}{
+		"flipped byte":   {flipped, "checksum"},
+		"future version": {withCRC(centroidFile(ModelVersion+1, 1)), "unsupported model version"},
+		"trailing bytes": {trailing, "trailing bytes"},
+		"oversized":      {withCRC(centroidFile(1, 1<<30)), "more than the file holds"},
+		"not a model":    {[]byte("hello, world"), "not a binary model"},
+	} {
+		_, err := ReadModelBinary(bytes.NewReader(tc.data))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: got error %v, want %q", name, err, tc.want)
+		}
+	}
+}
+
+func TestWriteModelInvalid(t *testing.T) {
+	for name, m := range map[string]Model{
+		"no centroids": &CentroidModel{},
+		"negative k":   &CentroidModel{Centroids: []Point{{}}, K: -1},
+		"noise label":  &DBSCANModel{Eps: 1, MinPts: 1, Core: []Point{{}}, Labels: []int{Noise}},
+		"zero scale":   &ScaledModel{Model: &CentroidModel{Centroids: []Point{{}}}},
+	} {
+		if err := WriteModelBinary(&bytes.Buffer{}, m); err == nil {
+			t.Errorf("%s: want error", name)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package cluster
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+// Noise - метка точки, не попавшей ни в один кластер. Кластеры нумеруются с 1.
+const Noise = 0
+
+// Point - точка на плоскости с весом, нулевой вес считается за 1.
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	W float64 `json:"w,omitempty"`
+}
+
+// Weight возвращает вес точки, 1 если вес не задан.
+func (p Point) Weight() float64 {
+	if p.W == 0 {
+		return 1
+	}
+	return p.W
+}
+
+// Distance возвращает евклидово расстояние между точками.
+func (p Point) Distance(q Point) float64 {
+	return math.Hypot(p.X-q.X, p.Y-q.Y)
+}
+
+// XYs переводит точки в формат gonum/plot.
+func XYs(points []Point) plotter.XYs {
+	xys := make(plotter.XYs, len(points))
+	for i, p := range points {
+		xys[i] = plotter.XY{X: p.X, Y: p.Y}
+	}
+	return xys
+}
+
+// CheckLabels проверяет, что метки неотрицательны: кластеры нумеруются
+// с 1, шум помечается Noise, а принятая в других библиотеках метка -1 не
+// поддерживается.
+func CheckLabels(labels []int) error {
+	for i, l := range labels {
+		if l < 0 {
+			return fmt.Errorf("point %d has negative label %d, clusters are numbered from 1 and noise is %d", i, l, Noise)
+		}
+	}
+	return nil
+}
+
+// Split раскладывает точки по кластерам согласно меткам: i-й элемент
+// clusters - точки кластера i+1, noise - точки с меткой Noise. Отрицательные
+// метки дают ошибку.
+func Split(points []Point, labels []int) (clusters []plotter.XYs, noise plotter.XYs, err error) {
+	if err := CheckLabels(labels); err != nil {
+		return nil, nil, err
+	}
+	clusters, noise = split(points, labels)
+	return clusters, noise, nil
+}
+
+func split(points []Point, labels []int) (clusters []plotter.XYs, noise plotter.XYs) {
+	for i, p := range points {
+		xy := plotter.XY{X: p.X, Y: p.Y}
+		if labels[i] == Noise {
+			noise = append(noise, xy)
+			continue
+		}
+		for len(clusters) < labels[i] {
+			clusters = append(clusters, nil)
+		}
+		clusters[labels[i]-1] = append(clusters[labels[i]-1], xy)
+	}
+	return clusters, noise
+}
+
+// Count возвращает количество кластеров и количество точек шума.
+func Count(labels []int) (clusters, noise int) {
+	for _, l := range labels {
+		if l == Noise {
+			noise++
+		}
+		clusters = max(clusters, l)
+	}
+	return clusters, noise
+}
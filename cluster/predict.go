@@ -0,0 +1,115 @@
+package cluster
+
+import (
+	"errors"
+	"math"
+
+	"algos/parallel"
+)
+
+// Model - обученная кластеризация, относящая новые точки к ее кластерам.
+type Model interface {
+	// Predict возвращает метку кластера точки или Noise.
+	Predict(p Point) int
+}
+
+// PredictBatch размечает точки моделью, распределяя их между workers
+// воркерами (workers <= 0 - по количеству ядер).
+func PredictBatch(m Model, points []Point, workers int) []int {
+	return parallel.Map(len(points), workers, func(i int) int {
+		return m.Predict(points[i])
+	})
+}
+
+// CentroidModel относит точку к кластеру ближайшего центроида,
+// i-й центроид - кластера i+1. Подходит для всех вариантов k-средних.
+type CentroidModel struct {
+	Centroids []Point
+	// Algorithm и K - алгоритм, построивший центроиды, и заданное число
+	// кластеров (0, если алгоритм его не принимает). Способ инициализации
+	// k-средних входит в имя алгоритма: kmeans или kmeans++.
+	Algorithm string
+	K         int
+}
+
+// Predict реализует Model.
+func (m *CentroidModel) Predict(p Point) int {
+	if len(m.Centroids) == 0 {
+		return Noise
+	}
+	return nearest(p, m.Centroids) + 1
+}
+
+// DBSCANModel относит точку к кластеру ближайшей ядровой точки в пределах eps.
+// Точка, до которой не дотягивается ни одна ядровая, считается шумом.
+type DBSCANModel struct {
+	Eps    float64
+	MinPts int
+	// Core - ядровые точки, Labels - их метки.
+	Core   []Point
+	Labels []int
+}
+
+// NewDBSCANModel выбирает ядровые точки из размеченного набора.
+func NewDBSCANModel(points []Point, labels []int, core []bool, eps float64, minPts int) *DBSCANModel {
+	m := &DBSCANModel{Eps: eps, MinPts: minPts}
+	for i, p := range points {
+		if core[i] {
+			m.Core = append(m.Core, p)
+			m.Labels = append(m.Labels, labels[i])
+		}
+	}
+	return m
+}
+
+// Predict реализует Model.
+func (m *DBSCANModel) Predict(p Point) int {
+	label, best := Noise, math.Inf(1)
+	for i, c := range m.Core {
+		if d := p.Distance(c); d <= m.Eps && d < best {
+			label, best = m.Labels[i], d
+		}
+	}
+	return label
+}
+
+// Scaling - масштабирование точек, на которых обучена модель: сначала
+// (v - Shift) / Scale по каждой координате, затем при Unit деление точки
+// на ее длину. Строится пакетом preprocess и сохраняется вместе с моделью.
+type Scaling struct {
+	// Name - название масштабирования: zscore, minmax, robust или unit.
+	Name  string     `json:"name"`
+	Shift [2]float64 `json:"shift"`
+	Scale [2]float64 `json:"scale"`
+	Unit  bool       `json:"unit,omitempty"`
+}
+
+// Apply переводит точку в масштаб модели, вес не меняется.
+func (s *Scaling) Apply(p Point) Point {
+	q := Point{X: (p.X - s.Shift[0]) / s.Scale[0], Y: (p.Y - s.Shift[1]) / s.Scale[1], W: p.W}
+	if norm := math.Hypot(q.X, q.Y); s.Unit && norm > 0 {
+		q.X, q.Y = q.X/norm, q.Y/norm
+	}
+	return q
+}
+
+// Invert возвращает точку в исходные единицы. Деление на длину обратить
+// нельзя, поэтому при Unit возвращается ошибка.
+func (s *Scaling) Invert(p Point) (Point, error) {
+	if s.Unit {
+		return Point{}, errors.New("unit norm scaling can not be inverted")
+	}
+	return Point{X: p.X*s.Scale[0] + s.Shift[0], Y: p.Y*s.Scale[1] + s.Shift[1], W: p.W}, nil
+}
+
+// ScaledModel - модель, обученная на отмасштабированных точках: Predict
+// сначала переводит точку в масштаб модели.
+type ScaledModel struct {
+	Model   Model
+	Scaling Scaling
+}
+
+// Predict реализует Model.
+func (m *ScaledModel) Predict(p Point) int {
+	return m.Model.Predict(m.Scaling.Apply(p))
+}
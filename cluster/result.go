@@ -0,0 +1,314 @@
+package cluster
+
+import (
+	"fmt"
+
+	"algos/distmat"
+	"algos/tools"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+// Clusterer - алгоритм кластеризации с заданными параметрами.
+type Clusterer interface {
+	// Fit размечает точки и возвращает результат кластеризации.
+	Fit(points []Point) (*Result, error)
+}
+
+// Result - результат кластеризации, общий для всех алгоритмов.
+type Result struct {
+	Algorithm string `json:"algorithm"`
+	// Labels - метки точек: кластеры нумеруются с 1, шум помечается Noise.
+	// Методы Result не проверяют метки, это делает NewResult.
+	Labels []int `json:"labels"`
+	// Members - индексы точек кластеров, i-й элемент - кластера i+1.
+	Members [][]int `json:"members"`
+	// Centroids - центры кластеров, если алгоритм их строит.
+	Centroids []Point `json:"centroids,omitempty"`
+	// Exemplars - индексы точек-образцов кластеров, если алгоритм их
+	// выбирает, i-й элемент - кластера i+1.
+	Exemplars []int `json:"exemplars,omitempty"`
+	// Meta - параметры алгоритма и сведения о ходе работы.
+	Meta map[string]any `json:"meta,omitempty"`
+	// Model относит новые точки к найденным кластерам, nil если алгоритм
+	// этого не умеет.
+	Model Model `json:"-"`
+}
+
+// Predict относит новую точку к кластеру, без модели возвращает Noise.
+func (r *Result) Predict(p Point) int {
+	if r.Model == nil {
+		return Noise
+	}
+	return r.Model.Predict(p)
+}
+
+// NewResult собирает результат по меткам точек, отрицательные метки дают
+// ошибку.
+func NewResult(algorithm string, labels []int, centroids []Point) (*Result, error) {
+	if err := CheckLabels(labels); err != nil {
+		return nil, err
+	}
+	return newResult(algorithm, labels, centroids), nil
+}
+
+// newResult собирает результат по меткам, полученным алгоритмами пакета.
+func newResult(algorithm string, labels []int, centroids []Point) *Result {
+	k, _ := Count(labels)
+	r := &Result{
+		Algorithm: algorithm,
+		Labels:    labels,
+		Members:   make([][]int, k),
+		Centroids: centroids,
+		Meta:      make(map[string]any),
+	}
+	for i, l := range labels {
+		if l != Noise {
+			r.Members[l-1] = append(r.Members[l-1], i)
+		}
+	}
+	return r
+}
+
+// Count возвращает количество кластеров и количество точек шума.
+func (r *Result) Count() (clusters, noise int) {
+	return Count(r.Labels)
+}
+
+// Noise возвращает индексы точек шума.
+func (r *Result) Noise() []int {
+	var noise []int
+	for i, l := range r.Labels {
+		if l == Noise {
+			noise = append(noise, i)
+		}
+	}
+	return noise
+}
+
+// Split раскладывает точки по кластерам для drawer: i-й элемент clusters -
+// точки кластера i+1, noise - точки шума.
+func (r *Result) Split(points []Point) (clusters []plotter.XYs, noise plotter.XYs) {
+	return split(points, r.Labels)
+}
+
+// Centers возвращает центроиды алгоритма, а если их нет - центры кластеров.
+func (r *Result) Centers(points []Point) []Point {
+	if r.Centroids != nil {
+		return r.Centroids
+	}
+	return centers(points, r.Labels)
+}
+
+// Evaluate считает показатели качества разбиения.
+func (r *Result) Evaluate(points []Point) Metrics {
+	return evaluate(points, r.Labels)
+}
+
+// DBSCANClusterer - кластеризация DBSCAN, см. DBSCAN.
+type DBSCANClusterer struct {
+	Eps    float64
+	MinPts int
+	// Workers - число воркеров для матрицы расстояний, <= 0 - все ядра.
+	Workers int
+	// Observe, если задан, получает каждый шаг расширения кластеров.
+	Observe DBSCANObserver
+}
+
+// Fit реализует Clusterer.
+func (c DBSCANClusterer) Fit(points []Point) (*Result, error) {
+	labels, core, err := dbscan(points, c.Eps, c.MinPts, c.Workers, c.Observe)
+	if err != nil {
+		return nil, err
+	}
+	r := newResult("dbscan", labels, nil)
+	r.Model = NewDBSCANModel(points, labels, core, c.Eps, c.MinPts)
+	r.Meta["eps"] = c.Eps
+	r.Meta["minPts"] = c.MinPts
+	return r, nil
+}
+
+// Init - способ выбора начальных центроидов k-средних.
+type Init int
+
+const (
+	// RandomInit - случайные точки набора.
+	RandomInit Init = iota
+	// PlusPlusInit - выбор k-means++.
+	PlusPlusInit
+)
+
+// KMeansClusterer - кластеризация k-средних, см. KMeans и KMeansPP.
+type KMeansClusterer struct {
+	K    int
+	Init Init
+	// Observe, если задан, получает каждую итерацию.
+	Observe KMeansObserver
+}
+
+// Fit реализует Clusterer.
+func (c KMeansClusterer) Fit(points []Point) (*Result, error) {
+	var (
+		labels    []int
+		centroids []Point
+		err       error
+		name      string
+	)
+	switch c.Init {
+	case RandomInit:
+		name = "kmeans"
+		labels, centroids, err = kMeans(points, c.K, c.Observe)
+	case PlusPlusInit:
+		name = "kmeans++"
+		labels, centroids, err = kMeansPP(points, c.K, c.Observe)
+	default:
+		return nil, fmt.Errorf("unknown k-means init %d", c.Init)
+	}
+	if err != nil {
+		return nil, err
+	}
+	r := newResult(name, labels, centroids)
+	r.Model = &CentroidModel{Centroids: centroids, Algorithm: name, K: c.K}
+	r.Meta["k"] = c.K
+	return r, nil
+}
+
+// MuesliClusterer - кластеризация библиотекой muesli/kmeans, см. MuesliKMeans.
+type MuesliClusterer struct {
+	K int
+}
+
+// Fit реализует Clusterer.
+func (c MuesliClusterer) Fit(points []Point) (*Result, error) {
+	labels, centroids, err := MuesliKMeans(points, c.K)
+	if err != nil {
+		return nil, err
+	}
+	r := newResult("muesli", labels, centroids)
+	r.Model = &CentroidModel{Centroids: centroids, Algorithm: "muesli", K: c.K}
+	r.Meta["k"] = c.K
+	return r, nil
+}
+
+// HierarchicalClusterer - агломеративная кластеризация, см. Hierarchical.
+type HierarchicalClusterer struct {
+	K       int
+	Linkage Linkage
+}
+
+// Fit реализует Clusterer.
+func (c HierarchicalClusterer) Fit(points []Point) (*Result, error) {
+	labels, err := Hierarchical(points, c.K, c.Linkage)
+	if err != nil {
+		return nil, err
+	}
+	r := newResult("hierarchical", labels, nil)
+	r.Meta["k"] = c.K
+	r.Meta["linkage"] = c.Linkage.String()
+	return r, nil
+}
+
+// SpectralClusterer - спектральная кластеризация, см. Spectral. В Meta
+// записываются фактически использованные параметры графа: neighbors для
+// kNN, sigma для RBF с заданной шириной или scaleNeighbor для RBF с
+// локальным масштабом.
+type SpectralClusterer struct {
+	K         int
+	Affinity  Affinity
+	Sigma     float64
+	Neighbors int
+}
+
+// Fit реализует Clusterer.
+func (c SpectralClusterer) Fit(points []Point) (*Result, error) {
+	labels, eigenvalues, params, err := spectral(points, c.K, c.Affinity, c.Sigma, c.Neighbors)
+	if err != nil {
+		return nil, err
+	}
+	r := newResult("spectral", labels, nil)
+	r.Meta["k"] = c.K
+	r.Meta["affinity"] = c.Affinity.String()
+	switch {
+	case c.Affinity == KNNAffinity:
+		r.Meta["neighbors"] = params.neighbors
+	case params.sigma > 0:
+		r.Meta["sigma"] = params.sigma
+	default:
+		r.Meta["scaleNeighbor"] = params.scaleNeighbor
+	}
+	r.Meta["eigenvalues"] = eigenvalues
+	return r, nil
+}
+
+// AffinityPropagationClusterer - кластеризация распространением сходства,
+// см. AffinityPropagation. Центроиды результата - точки-образцы, веса точек
+// не учитываются. Preference == nil - медиана сходств, в Meta записываются
+// фактически использованные preference и damping.
+type AffinityPropagationClusterer struct {
+	Preference *float64
+	Damping    float64
+}
+
+// Fit реализует Clusterer.
+func (c AffinityPropagationClusterer) Fit(points []Point) (*Result, error) {
+	dm := distmat.New[float64](len(points), func(i, j int) float64 {
+		return points[i].Distance(points[j])
+	}, 0)
+	ap, err := affinityPropagation(dm, c.Preference, c.Damping)
+	if err != nil {
+		return nil, err
+	}
+	centroids := make([]Point, len(ap.exemplars))
+	for i, k := range ap.exemplars {
+		centroids[i] = points[k]
+	}
+	r := newResult("affinity", ap.labels, centroids)
+	r.Exemplars = ap.exemplars
+	r.Model = &CentroidModel{Centroids: centroids, Algorithm: "affinity"}
+	r.Meta["preference"] = ap.preference
+	r.Meta["damping"] = ap.damping
+	r.Meta["iterations"] = ap.iterations
+	r.Meta["converged"] = ap.converged
+	return r, nil
+}
+
+// COPKMeansClusterer - k-средних с ограничениями, см. COPKMeans. Нарушенные
+// ограничения записываются в Meta["violations"].
+type COPKMeansClusterer struct {
+	K           int
+	Constraints *tools.Constraints
+}
+
+// Fit реализует Clusterer.
+func (c COPKMeansClusterer) Fit(points []Point) (*Result, error) {
+	labels, centroids, violations, err := COPKMeans(points, c.K, c.Constraints)
+	if err != nil {
+		return nil, err
+	}
+	r := newResult("cop-kmeans", labels, centroids)
+	r.Model = &CentroidModel{Centroids: centroids, Algorithm: "cop-kmeans", K: c.K}
+	r.Meta["k"] = c.K
+	r.Meta["violations"] = violations
+	return r, nil
+}
+
+// ConstrainedDBSCANClusterer - DBSCAN с ограничениями, см. ConstrainedDBSCAN.
+// Невыполненные ограничения записываются в Meta["violations"].
+type ConstrainedDBSCANClusterer struct {
+	Eps         float64
+	MinPts      int
+	Constraints *tools.Constraints
+}
+
+// Fit реализует Clusterer.
+func (c ConstrainedDBSCANClusterer) Fit(points []Point) (*Result, error) {
+	labels, violations, err := ConstrainedDBSCAN(points, c.Eps, c.MinPts, c.Constraints)
+	if err != nil {
+		return nil, err
+	}
+	r := newResult("constrained-dbscan", labels, nil)
+	r.Meta["eps"] = c.Eps
+	r.Meta["minPts"] = c.MinPts
+	r.Meta["violations"] = violations
+	return r, nil
+}
@@ -0,0 +1,313 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"algos/distmat"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// spectralRestarts - число запусков k-средних в спектральном пространстве,
+// выбирается разбиение с наименьшей инерцией.
+const spectralRestarts = 10
+
+// scaleNeighbor - номер соседа, расстояние до которого задает локальный
+// масштаб точки в RBF без заданной sigma (Zelnik-Manor, Perona).
+const scaleNeighbor = 7
+
+// defaultNeighbors - число соседей графа kNN по умолчанию.
+const defaultNeighbors = 10
+
+// affinityParams - фактически использованные параметры графа сходства.
+type affinityParams struct {
+	// sigma - ширина ядра RBF, 0 при локальном масштабе.
+	sigma float64
+	// scaleNeighbor - номер соседа локального масштаба, 0 при общей sigma.
+	scaleNeighbor int
+	// neighbors - число соседей графа kNN.
+	neighbors int
+}
+
+// Affinity - способ строить граф сходства точек.
+type Affinity int
+
+const (
+	// RBFAffinity - полный граф с весами exp(-d²/2σ²), без заданной σ -
+	// exp(-d²/σᵢσⱼ) с локальным масштабом σᵢ каждой точки.
+	RBFAffinity Affinity = iota
+	// KNNAffinity - граф k ближайших соседей с единичными весами,
+	// ребро есть, если одна из точек входит в соседи другой.
+	KNNAffinity
+)
+
+func (a Affinity) String() string {
+	switch a {
+	case RBFAffinity:
+		return "rbf"
+	case KNNAffinity:
+		return "knn"
+	}
+	return fmt.Sprintf("Affinity(%d)", int(a))
+}
+
+// ParseAffinity разбирает название графа сходства: rbf или knn.
+func ParseAffinity(s string) (Affinity, error) {
+	switch s {
+	case "rbf":
+		return RBFAffinity, nil
+	case "knn":
+		return KNNAffinity, nil
+	}
+	return 0, fmt.Errorf("unknown affinity %q, want rbf or knn", s)
+}
+
+// Spectral - спектральная кластеризация (Ng, Jordan, Weiss): точки
+// вкладываются в пространство k старших собственных векторов
+// нормированной матрицы сходства D^-1/2 A D^-1/2 (младших векторов
+// нормированного лапласиана), строки вложения нормируются и делятся
+// k-средними. В отличие от k-средних на исходных координатах находит
+// кластеры любой формы, если они связны в графе сходства.
+//
+// sigma - ширина ядра RBF; при sigma <= 0 у каждой точки свой масштаб -
+// расстояние до ее 7-го соседа, так что плотные и разреженные кластеры
+// разделяются одинаково. neighbors - число соседей графа kNN, при
+// neighbors <= 0 берется 10. Вес ребра умножается на веса обеих точек.
+// Метки нумеруются с 1 в порядке первой точки кластера.
+func Spectral(points []Point, k int, affinity Affinity, sigma float64, neighbors int) ([]int, error) {
+	labels, _, _, err := spectral(points, k, affinity, sigma, neighbors)
+	return labels, err
+}
+
+// spectral размечает точки и возвращает k+1 младших собственных значений
+// нормированного лапласиана (большой разрыв после k-го говорит о том,
+// что k выбрано удачно) и параметры построенного графа сходства.
+func spectral(points []Point, k int, affinity Affinity, sigma float64, neighbors int) ([]int, []float64, affinityParams, error) {
+	n := len(points)
+	if k <= 0 || k > n {
+		return nil, nil, affinityParams{}, fmt.Errorf("k must be in [1, %d], got %d", n, k)
+	}
+	a, params, err := affinityMatrix(points, affinity, sigma, neighbors)
+	if err != nil {
+		return nil, nil, affinityParams{}, err
+	}
+
+	// M = D^-1/2 A D^-1/2, у изолированных точек строка остается нулевой
+	invSqrt := make([]float64, n)
+	for i := 0; i < n; i++ {
+		var degree float64
+		for j := 0; j < n; j++ {
+			degree += a.At(i, j)
+		}
+		if degree > 0 {
+			invSqrt[i] = 1 / math.Sqrt(degree)
+		}
+	}
+	m := mat.NewSymDense(n, nil)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			m.SetSym(i, j, invSqrt[i]*a.At(i, j)*invSqrt[j])
+		}
+	}
+
+	var eig mat.EigenSym
+	if !eig.Factorize(m, true) {
+		return nil, nil, affinityParams{}, errors.New("eigendecomposition did not converge")
+	}
+	// собственные значения M идут по возрастанию, значения лапласиана - 1 - λ
+	values := eig.Values(nil)
+	var vectors mat.Dense
+	eig.VectorsTo(&vectors)
+
+	gaps := make([]float64, min(k+1, n))
+	for c := range gaps {
+		gaps[c] = 1 - values[n-1-c]
+	}
+	embedding := make([][]float64, n)
+	for i := range embedding {
+		row := make([]float64, k)
+		var norm float64
+		for c := range row {
+			row[c] = vectors.At(i, n-1-c)
+			norm += row[c] * row[c]
+		}
+		if norm > 0 {
+			norm = math.Sqrt(norm)
+			for c := range row {
+				row[c] /= norm
+			}
+		}
+		embedding[i] = row
+	}
+
+	labels := kMeansRows(embedding, k, spectralRestarts)
+	return relabel(labels), gaps, params, nil
+}
+
+// affinityMatrix строит симметричную матрицу сходства с нулевой диагональю
+// и возвращает фактически использованные параметры.
+func affinityMatrix(points []Point, affinity Affinity, sigma float64, neighbors int) (*mat.SymDense, affinityParams, error) {
+	n := len(points)
+	dm := distmat.New[float64](n, func(i, j int) float64 {
+		return points[i].Distance(points[j])
+	}, 0)
+	a := mat.NewSymDense(n, nil)
+	var params affinityParams
+	switch affinity {
+	case RBFAffinity:
+		// scale[i]*scale[j] - квадрат ширины ядра для пары точек
+		scale := make([]float64, n)
+		if sigma > 0 {
+			params.sigma = sigma
+			for i := range scale {
+				scale[i] = math.Sqrt2 * sigma
+			}
+		} else {
+			params.scaleNeighbor = min(scaleNeighbor, n-1)
+			for i := range scale {
+				if nn := dm.KNearest(i, params.scaleNeighbor); len(nn) > 0 {
+					scale[i] = dm.At(i, nn[len(nn)-1])
+				}
+			}
+		}
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				// совпадающие точки связаны с весом 1, в том числе когда
+				// совпадений больше scaleNeighbor и масштаб нулевой
+				d, s := dm.At(i, j), scale[i]*scale[j]
+				switch {
+				case d == 0:
+					a.SetSym(i, j, 1)
+				case s > 0:
+					a.SetSym(i, j, math.Exp(-d*d/s))
+				}
+			}
+		}
+	case KNNAffinity:
+		if neighbors <= 0 {
+			neighbors = defaultNeighbors
+		}
+		params.neighbors = min(neighbors, n-1)
+		for i := 0; i < n; i++ {
+			for _, j := range dm.KNearest(i, neighbors) {
+				a.SetSym(i, j, 1)
+			}
+		}
+	default:
+		return nil, params, fmt.Errorf("unknown affinity %d", affinity)
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			a.SetSym(i, j, a.At(i, j)*points[i].Weight()*points[j].Weight())
+		}
+	}
+	return a, params, nil
+}
+
+// kMeansRows делит строки произвольной размерности на k кластеров:
+// restarts запусков k-means++ с итерациями Ллойда, лучший по инерции.
+// Возвращает метки с 1.
+func kMeansRows(rows [][]float64, k, restarts int) []int {
+	var (
+		best        []int
+		bestInertia = math.Inf(1)
+	)
+	for r := 0; r < restarts; r++ {
+		labels, inertia := lloydRows(rows, seedRows(rows, k))
+		if inertia < bestInertia {
+			best, bestInertia = labels, inertia
+		}
+	}
+	return best
+}
+
+// seedRows выбирает начальные центроиды k-means++.
+func seedRows(rows [][]float64, k int) [][]float64 {
+	centroids := make([][]float64, 0, k)
+	scores := make([]float64, len(rows))
+	for i := range scores {
+		scores[i] = 1
+	}
+	for len(centroids) < k {
+		centroids = append(centroids, append([]float64(nil), rows[sample(scores)]...))
+		for i, row := range rows {
+			_, scores[i] = nearestRow(row, centroids)
+		}
+	}
+	return centroids
+}
+
+// lloydRows уточняет центроиды и возвращает метки с 1 и инерцию.
+func lloydRows(rows, centroids [][]float64) ([]int, float64) {
+	k, dim := len(centroids), len(rows[0])
+	labels := make([]int, len(rows))
+	var inertia float64
+	for iter := 0; iter < kMeansMaxIter; iter++ {
+		changed := false
+		inertia = 0
+		for i, row := range rows {
+			c, d := nearestRow(row, centroids)
+			if labels[i] != c+1 {
+				labels[i], changed = c+1, true
+			}
+			inertia += d
+		}
+		if !changed {
+			break
+		}
+
+		// пустой кластер сохраняет прежний центроид
+		sums := make([][]float64, k)
+		counts := make([]int, k)
+		for c := range sums {
+			sums[c] = make([]float64, dim)
+		}
+		for i, row := range rows {
+			c := labels[i] - 1
+			counts[c]++
+			for j, v := range row {
+				sums[c][j] += v
+			}
+		}
+		for c := range centroids {
+			if counts[c] == 0 {
+				continue
+			}
+			for j := range sums[c] {
+				sums[c][j] /= float64(counts[c])
+			}
+			centroids[c] = sums[c]
+		}
+	}
+	return labels, inertia
+}
+
+// nearestRow возвращает индекс ближайшего центроида и квадрат расстояния до него.
+func nearestRow(row []float64, centroids [][]float64) (int, float64) {
+	closest, closestDist := -1, math.Inf(1)
+	for c, centroid := range centroids {
+		var d float64
+		for j, v := range row {
+			d += (v - centroid[j]) * (v - centroid[j])
+		}
+		if d < closestDist {
+			closest, closestDist = c, d
+		}
+	}
+	return closest, closestDist
+}
+
+// relabel перенумеровывает метки с 1 в порядке первой точки кластера.
+func relabel(labels []int) []int {
+	ids := make(map[int]int)
+	result := make([]int, len(labels))
+	for i, l := range labels {
+		if _, ok := ids[l]; !ok {
+			ids[l] = len(ids) + 1
+		}
+		result[i] = ids[l]
+	}
+	return result
+}
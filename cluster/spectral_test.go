@@ -0,0 +1,77 @@
+package cluster
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// twoMoons возвращает две вложенные полудуги по n точек с гауссовым
+// шумом noise и их истинные метки.
+func twoMoons(n int, noise float64, rng *rand.Rand) ([]Point, []int) {
+	points := make([]Point, 0, 2*n)
+	labels := make([]int, 0, 2*n)
+	for i := 0; i < n; i++ {
+		t := math.Pi * float64(i) / float64(n-1)
+		points = append(points, Point{X: math.Cos(t), Y: math.Sin(t)})
+		points = append(points, Point{X: 1 - math.Cos(t), Y: 0.5 - math.Sin(t)})
+		labels = append(labels, 1, 2)
+	}
+	for i := range points {
+		points[i].X += rng.NormFloat64() * noise
+		points[i].Y += rng.NormFloat64() * noise
+	}
+	return points, labels
+}
+
+// mislabeled возвращает долю точек, размеченных не так, как want, с
+// точностью до перестановки двух кластеров.
+func mislabeled(got, want []int) float64 {
+	var same int
+	for i := range got {
+		if got[i] == want[i] {
+			same++
+		}
+	}
+	wrong := min(same, len(got)-same)
+	return float64(wrong) / float64(len(got))
+}
+
+func TestSpectralTwoMoons(t *testing.T) {
+	points, want := twoMoons(100, 0.05, rand.New(rand.NewSource(1)))
+	for _, c := range []SpectralClusterer{
+		{K: 2, Affinity: RBFAffinity},
+		{K: 2, Affinity: KNNAffinity},
+	} {
+		res, err := c.Fit(points)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f := mislabeled(res.Labels, want); f > 0.02 {
+			t.Errorf("%s: %.0f%% of the points are mislabeled", c.Affinity, f*100)
+		}
+	}
+}
+
+func TestSpectralMeta(t *testing.T) {
+	points := Generate(20, 2, rand.New(rand.NewSource(2)))
+	for _, tc := range []struct {
+		c     SpectralClusterer
+		key   string
+		value any
+	}{
+		{SpectralClusterer{K: 2, Affinity: RBFAffinity}, "scaleNeighbor", 7},
+		{SpectralClusterer{K: 2, Affinity: RBFAffinity, Sigma: 3}, "sigma", 3.0},
+		{SpectralClusterer{K: 2, Affinity: KNNAffinity}, "neighbors", 10},
+		// соседей не больше, чем других точек
+		{SpectralClusterer{K: 2, Affinity: KNNAffinity, Neighbors: 50}, "neighbors", 19},
+	} {
+		res, err := tc.c.Fit(points)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := res.Meta[tc.key]; got != tc.value {
+			t.Errorf("%+v: Meta[%s] = %v, want %v", tc.c, tc.key, got, tc.value)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package distmat
+
+import (
+	"algos/parallel"
+	"cmp"
+	"slices"
+)
+
+// Float - допустимые типы хранения расстояний.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Matrix хранит попарные расстояния между n точками в сжатом виде:
+// только верхний треугольник без диагонали, n*(n-1)/2 значений.
+// Ленивая матрица считает расстояния при первом обращении и не
+// предназначена для одновременного использования из нескольких горутин.
+type Matrix[T Float] struct {
+	n      int
+	data   []T
+	filled []bool
+	dist   func(i, j int) float64
+}
+
+// New создает матрицу и сразу заполняет ее, распределяя строки между
+// воркерами (workers <= 0 - все ядра).
+func New[T Float](n int, dist func(i, j int) float64, workers int) *Matrix[T] {
+	m := &Matrix[T]{n: n, data: make([]T, n*(n-1)/2), dist: dist}
+	parallel.For(n, workers, func(i int) {
+		for j := i + 1; j < n; j++ {
+			m.data[m.index(i, j)] = T(dist(i, j))
+		}
+	})
+	return m
+}
+
+// NewLazy создает матрицу, которая считает расстояния по мере обращения к ним.
+func NewLazy[T Float](n int, dist func(i, j int) float64) *Matrix[T] {
+	size := n * (n - 1) / 2
+	return &Matrix[T]{n: n, data: make([]T, size), filled: make([]bool, size), dist: dist}
+}
+
+// Len возвращает количество точек.
+func (m *Matrix[T]) Len() int {
+	return m.n
+}
+
+// At возвращает расстояние между точками i и j.
+func (m *Matrix[T]) At(i, j int) T {
+	if i == j {
+		return 0
+	}
+	if i > j {
+		i, j = j, i
+	}
+	k := m.index(i, j)
+	if m.filled != nil && !m.filled[k] {
+		m.data[k] = T(m.dist(i, j))
+		m.filled[k] = true
+	}
+	return m.data[k]
+}
+
+// Values возвращает копию всех попарных расстояний в порядке (0,1), (0,2), ..., (n-2,n-1).
+func (m *Matrix[T]) Values() []T {
+	m.fill()
+	return slices.Clone(m.data)
+}
+
+// Median возвращает медиану попарных расстояний (элемент len/2 отсортированного ряда).
+// Если точек меньше двух, пар нет и результат 0.
+func (m *Matrix[T]) Median() T {
+	values := m.Values()
+	if len(values) == 0 {
+		return 0
+	}
+	slices.Sort(values)
+	return values[len(values)/2]
+}
+
+// WeightedMedian возвращает взвешенную медиану попарных расстояний, где вес
+// пары (i, j) равен weights[i]*weights[j]. При единичных весах совпадает с Median,
+// если точек меньше двух, результат 0.
+func (m *Matrix[T]) WeightedMedian(weights []float64) T {
+	m.fill()
+	pairWeights := make([]float64, 0, len(m.data))
+	for i := 0; i < m.n; i++ {
+		for j := i + 1; j < m.n; j++ {
+			pairWeights = append(pairWeights, weights[i]*weights[j])
+		}
+	}
+	return WeightedMedian(m.data, pairWeights)
+}
+
+// WeightedMedian возвращает первое по возрастанию значение, на котором
+// накопленный вес превышает половину общего веса. При единичных весах
+// это элемент len/2 отсортированного ряда. Для пустого ряда результат 0.
+func WeightedMedian[T Float](values []T, weights []float64) T {
+	if len(values) == 0 {
+		return 0
+	}
+	idx := make([]int, len(values))
+	total := 0.0
+	for i := range idx {
+		idx[i] = i
+		total += weights[i]
+	}
+	slices.SortStableFunc(idx, func(a, b int) int {
+		return cmp.Compare(values[a], values[b])
+	})
+
+	acc := 0.0
+	for _, i := range idx {
+		acc += weights[i]
+		if acc > total/2 {
+			return values[i]
+		}
+	}
+	return values[idx[len(idx)-1]]
+}
+
+// Nearest возвращает ближайшую к i точку и расстояние до нее.
+func (m *Matrix[T]) Nearest(i int) (int, T) {
+	to := -1
+	var best T
+	for j := 0; j < m.n; j++ {
+		if j == i {
+			continue
+		}
+		if d := m.At(i, j); to < 0 || d < best {
+			to, best = j, d
+		}
+	}
+	return to, best
+}
+
+// Farthest возвращает самую далекую от i точку и расстояние до нее.
+func (m *Matrix[T]) Farthest(i int) (int, T) {
+	to := -1
+	var best T
+	for j := 0; j < m.n; j++ {
+		if j == i {
+			continue
+		}
+		if d := m.At(i, j); to < 0 || d > best {
+			to, best = j, d
+		}
+	}
+	return to, best
+}
+
+// RowMin возвращает расстояние от каждой точки до ближайшей соседки.
+func (m *Matrix[T]) RowMin() []T {
+	result := make([]T, m.n)
+	for i := range result {
+		_, result[i] = m.Nearest(i)
+	}
+	return result
+}
+
+// RowMax возвращает расстояние от каждой точки до самой далекой.
+func (m *Matrix[T]) RowMax() []T {
+	result := make([]T, m.n)
+	for i := range result {
+		_, result[i] = m.Farthest(i)
+	}
+	return result
+}
+
+// Neighbors возвращает индексы точек на расстоянии не больше eps от i,
+// включая саму точку i, в порядке возрастания.
+func (m *Matrix[T]) Neighbors(i int, eps T) []int {
+	var result []int
+	for j := 0; j < m.n; j++ {
+		if m.At(i, j) <= eps {
+			result = append(result, j)
+		}
+	}
+	return result
+}
+
+// KNearest возвращает k ближайших к i точек без самой i, от ближней к дальней.
+func (m *Matrix[T]) KNearest(i, k int) []int {
+	result := make([]int, 0, max(m.n-1, 0))
+	for j := 0; j < m.n; j++ {
+		if j != i {
+			result = append(result, j)
+		}
+	}
+	slices.SortStableFunc(result, func(a, b int) int {
+		return cmp.Compare(m.At(i, a), m.At(i, b))
+	})
+	return result[:max(0, min(k, len(result)))]
+}
+
+func (m *Matrix[T]) fill() {
+	if m.filled == nil {
+		return
+	}
+	for i := 0; i < m.n; i++ {
+		for j := i + 1; j < m.n; j++ {
+			m.At(i, j)
+		}
+	}
+}
+
+func (m *Matrix[T]) index(i, j int) int {
+	return i*m.n - i*(i+1)/2 + j - i - 1
+}
@@ -0,0 +1,80 @@
+package distmat
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func line(xs ...float64) *Matrix[float64] {
+	return New[float64](len(xs), func(i, j int) float64 {
+		return math.Abs(xs[i] - xs[j])
+	}, 1)
+}
+
+func TestMedian(t *testing.T) {
+	for _, tt := range []struct {
+		xs   []float64
+		want float64
+	}{
+		{nil, 0},
+		{[]float64{5}, 0},
+		{[]float64{0, 2}, 2},
+		{[]float64{0, 1, 3}, 2},
+	} {
+		if got := line(tt.xs...).Median(); got != tt.want {
+			t.Errorf("Median(%v) = %v, want %v", tt.xs, got, tt.want)
+		}
+	}
+}
+
+func TestWeightedMedian(t *testing.T) {
+	if got := WeightedMedian[float64](nil, nil); got != 0 {
+		t.Errorf("WeightedMedian(empty) = %v, want 0", got)
+	}
+	if got := WeightedMedian([]float64{3, 1, 2}, []float64{1, 1, 5}); got != 2 {
+		t.Errorf("WeightedMedian = %v, want 2", got)
+	}
+	if got := line(7).WeightedMedian([]float64{1}); got != 0 {
+		t.Errorf("WeightedMedian of one point = %v, want 0", got)
+	}
+	// при единичных весах совпадает с Median
+	m := line(0, 1, 3, 7, 8)
+	if got, want := m.WeightedMedian([]float64{1, 1, 1, 1, 1}), m.Median(); got != want {
+		t.Errorf("WeightedMedian = %v, Median = %v", got, want)
+	}
+}
+
+func randomDist(n int) func(i, j int) float64 {
+	rnd := rand.New(rand.NewSource(1))
+	xs, ys := make([]float64, n), make([]float64, n)
+	for i := range xs {
+		xs[i], ys[i] = rnd.Float64()*1000, rnd.Float64()*1000
+	}
+	return func(i, j int) float64 {
+		return math.Hypot(xs[i]-xs[j], ys[i]-ys[j])
+	}
+}
+
+func TestNewWorkers(t *testing.T) {
+	dist := randomDist(101)
+	want := NewLazy[float64](101, dist).Values()
+	for _, w := range []int{1, 2, 3, 0, 200} {
+		if got := New[float64](101, dist, w).Values(); !slices.Equal(got, want) {
+			t.Errorf("workers=%d: values differ from the lazy matrix", w)
+		}
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	dist := randomDist(2000)
+	for _, w := range []int{1, 2, 4, 0} {
+		b.Run(fmt.Sprintf("workers=%d", w), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				New[float64](2000, dist, w)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package drawer
+
+import (
+	"fmt"
+	"image"
+	"image/color/palette"
+	imagedraw "image/draw"
+	"image/gif"
+	"image/png"
+	"io"
+	"math"
+	"os"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+// Frame is one step of a clustering animation.
+type Frame struct {
+	// Title replaces the plot title for this frame when set.
+	Title     string
+	Clusters  []plotter.XYs
+	Centroids plotter.XYs
+	Exemplars plotter.XYs
+	Noise     plotter.XYs
+}
+
+// WriteGIF renders the frames as an animated GIF. Delay is the time between
+// frames in 100ths of a second, the last frame is held three times longer.
+// Axis ranges are shared by all frames unless given in the options.
+func WriteGIF(w io.Writer, frames []Frame, delay int, opts ...Option) error {
+	o := frameOptions(frames, newOptions(512, 512, opts))
+
+	anim := &gif.GIF{}
+	for i, f := range frames {
+		img, err := renderFrame(f, o)
+		if err != nil {
+			return fmt.Errorf("could not render frame %d: %v", i, err)
+		}
+		paletted := image.NewPaletted(img.Bounds(), palette.Plan9)
+		imagedraw.FloydSteinberg.Draw(paletted, img.Bounds(), img, image.Point{})
+		anim.Image = append(anim.Image, paletted)
+		anim.Delay = append(anim.Delay, delay)
+	}
+	if len(anim.Delay) > 0 {
+		anim.Delay[len(anim.Delay)-1] = 3 * delay
+	}
+	return gif.EncodeAll(w, anim)
+}
+
+// SaveGIF writes the animated GIF to the file at path.
+func SaveGIF(path string, frames []Frame, delay int, opts ...Option) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create %s: %v", path, err)
+	}
+	if err := WriteGIF(f, frames, delay, opts...); err != nil {
+		f.Close()
+		return fmt.Errorf("could not write to %s: %v", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("could not close %s: %v", path, err)
+	}
+	return nil
+}
+
+// SaveFrames writes every frame as a numbered PNG: prefix000.png, prefix001.png, ...
+func SaveFrames(prefix string, frames []Frame, opts ...Option) error {
+	o := frameOptions(frames, newOptions(512, 512, opts))
+	for i, fr := range frames {
+		img, err := renderFrame(fr, o)
+		if err != nil {
+			return fmt.Errorf("could not render frame %d: %v", i, err)
+		}
+		path := fmt.Sprintf("%s%03d.png", prefix, i)
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("could not create %s: %v", path, err)
+		}
+		if err := png.Encode(f, img); err != nil {
+			f.Close()
+			return fmt.Errorf("could not write to %s: %v", path, err)
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("could not close %s: %v", path, err)
+		}
+	}
+	return nil
+}
+
+// frameOptions fixes the axis ranges over all frames so the picture does not jump.
+func frameOptions(frames []Frame, o Options) Options {
+	minX, maxX := math.Inf(1), math.Inf(-1)
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	add := func(xys plotter.XYs) {
+		for _, p := range xys {
+			minX, maxX = math.Min(minX, p.X), math.Max(maxX, p.X)
+			minY, maxY = math.Min(minY, p.Y), math.Max(maxY, p.Y)
+		}
+	}
+	for _, f := range frames {
+		for _, c := range f.Clusters {
+			add(c)
+		}
+		add(f.Centroids)
+		add(f.Noise)
+	}
+	if math.IsInf(minX, 1) {
+		return o
+	}
+
+	padX, padY := (maxX-minX)*0.05, (maxY-minY)*0.05
+	if !o.xRange {
+		o.XMin, o.XMax, o.xRange = minX-padX, maxX+padX, true
+	}
+	if !o.yRange {
+		o.YMin, o.YMax, o.yRange = minY-padY, maxY+padY, true
+	}
+	return o
+}
+
+// renderFrame draws one frame to an in-memory image.
+func renderFrame(f Frame, o Options) (image.Image, error) {
+	o.Centroids, o.Exemplars, o.Noise = f.Centroids, f.Exemplars, f.Noise
+	if f.Title != "" {
+		o.Title = f.Title
+	}
+	p, err := clusterPlot(f.Clusters, o)
+	if err != nil {
+		return nil, err
+	}
+	return newChart(p, o).Image(), nil
+}
@@ -0,0 +1,72 @@
+package drawer
+
+import (
+	"fmt"
+	"image"
+	"io"
+	"os"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+)
+
+// Chart is a plot ready to be rendered to a writer, an image or a file.
+type Chart struct {
+	draw func(c draw.Canvas)
+	o    Options
+}
+
+// newChart applies the options to the plot and wraps it.
+func newChart(p *plot.Plot, o Options) *Chart {
+	o.apply(p)
+	return &Chart{draw: p.Draw, o: o}
+}
+
+// WriteTo implements io.WriterTo. The format is taken from WithFormat, png by default.
+func (ch *Chart) WriteTo(w io.Writer) (int64, error) {
+	c, err := ch.canvas(ch.o.format(""))
+	if err != nil {
+		return 0, err
+	}
+	return c.WriteTo(w)
+}
+
+// canvas renders the chart in the given format.
+func (ch *Chart) canvas(format string) (vg.CanvasWriterTo, error) {
+	c, err := draw.NewFormattedCanvas(ch.o.Width, ch.o.Height, format)
+	if err != nil {
+		return nil, fmt.Errorf("could not create canvas: %v", err)
+	}
+	ch.draw(draw.New(c))
+	return c, nil
+}
+
+// Image renders the chart to an in-memory image.
+func (ch *Chart) Image() image.Image {
+	c := vgimg.New(ch.o.Width, ch.o.Height)
+	ch.draw(draw.New(c))
+	return c.Image()
+}
+
+// Save writes the chart to the file at path. The format is taken from
+// WithFormat or from the file extension.
+func (ch *Chart) Save(path string) error {
+	c, err := ch.canvas(ch.o.format(path))
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create %s: %v", path, err)
+	}
+	if _, err := c.WriteTo(f); err != nil {
+		f.Close()
+		return fmt.Errorf("could not write to %s: %v", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("could not close %s: %v", path, err)
+	}
+	return nil
+}
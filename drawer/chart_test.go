@@ -0,0 +1,55 @@
+package drawer
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+func testChart(t *testing.T, opts ...Option) *Chart {
+	t.Helper()
+	clusters := []plotter.XYs{{{X: 0, Y: 0}, {X: 1, Y: 1}}, {{X: 5, Y: 5}}}
+	ch, err := ClastersChart(clusters, append([]Option{WithSize(200, 100)}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ch
+}
+
+func TestChartWriteToPNG(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := testChart(t).WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("WriteTo reports %d bytes, wrote %d", n, buf.Len())
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("not a png: %v", err)
+	}
+	if want := testChart(t).Image().Bounds(); img.Bounds() != want {
+		t.Errorf("png is %v, want %v as Image", img.Bounds(), want)
+	}
+}
+
+func TestChartWriteToSVG(t *testing.T) {
+	var buf bytes.Buffer
+	if _, err := testChart(t, WithFormat("SVG")).WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	s := buf.String()
+	if !strings.Contains(s, "<svg") || !strings.HasSuffix(strings.TrimSpace(s), "</svg>") {
+		t.Errorf("not an svg document: %.80q", s)
+	}
+}
+
+func TestChartUnknownFormat(t *testing.T) {
+	if _, err := testChart(t, WithFormat("bmp")).WriteTo(&bytes.Buffer{}); err == nil {
+		t.Error("want error for an unknown format")
+	}
+}
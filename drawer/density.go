@@ -0,0 +1,213 @@
+package drawer
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/plotter"
+)
+
+// DensityGrid is a point density sampled on a regular grid. It implements
+// plotter.GridXYZ, so it can be drawn by gonum/plot heat maps and contours.
+type DensityGrid struct {
+	Cols, Rows int
+	MinX, MaxX float64
+	MinY, MaxY float64
+	// Values are stored row by row, Values[r*Cols+c].
+	Values []float64
+}
+
+func newDensityGrid(xys plotter.XYs, cols, rows int, pad float64) *DensityGrid {
+	g := &DensityGrid{
+		Cols: cols, Rows: rows,
+		MinX: math.Inf(1), MaxX: math.Inf(-1),
+		MinY: math.Inf(1), MaxY: math.Inf(-1),
+		Values: make([]float64, cols*rows),
+	}
+	for _, p := range xys {
+		g.MinX, g.MaxX = math.Min(g.MinX, p.X), math.Max(g.MaxX, p.X)
+		g.MinY, g.MaxY = math.Min(g.MinY, p.Y), math.Max(g.MaxY, p.Y)
+	}
+	padX, padY := (g.MaxX-g.MinX)*pad, (g.MaxY-g.MinY)*pad
+	if padX == 0 {
+		padX = 0.5
+	}
+	if padY == 0 {
+		padY = 0.5
+	}
+	g.MinX, g.MaxX = g.MinX-padX, g.MaxX+padX
+	g.MinY, g.MaxY = g.MinY-padY, g.MaxY+padY
+	return g
+}
+
+// Histogram2D counts points in cols x rows equal cells over the data range.
+func Histogram2D(xys plotter.XYs, cols, rows int) (*DensityGrid, error) {
+	if len(xys) == 0 || cols <= 0 || rows <= 0 {
+		return nil, fmt.Errorf("need points and a positive grid size, got %d points and %dx%d", len(xys), cols, rows)
+	}
+	g := newDensityGrid(xys, cols, rows, 0.01)
+	for _, p := range xys {
+		c := int((p.X - g.MinX) / (g.MaxX - g.MinX) * float64(cols))
+		r := int((p.Y - g.MinY) / (g.MaxY - g.MinY) * float64(rows))
+		g.Values[min(r, rows-1)*cols+min(c, cols-1)]++
+	}
+	return g, nil
+}
+
+// KDE estimates the density with a Gaussian kernel on a cols x rows grid.
+// Bandwidth is the kernel standard deviation in data units; when it is not
+// positive, Scott's rule is used for each axis.
+func KDE(xys plotter.XYs, cols, rows int, bandwidth float64) (*DensityGrid, error) {
+	if len(xys) == 0 || cols <= 0 || rows <= 0 {
+		return nil, fmt.Errorf("need points and a positive grid size, got %d points and %dx%d", len(xys), cols, rows)
+	}
+
+	hx, hy := bandwidth, bandwidth
+	if bandwidth <= 0 {
+		xs := make([]float64, len(xys))
+		ys := make([]float64, len(xys))
+		for i, p := range xys {
+			xs[i], ys[i] = p.X, p.Y
+		}
+		scott := math.Pow(float64(len(xys)), -1.0/6)
+		hx, hy = stat.StdDev(xs, nil)*scott, stat.StdDev(ys, nil)*scott
+		if !(hx > 0) {
+			hx = 1
+		}
+		if !(hy > 0) {
+			hy = 1
+		}
+	}
+
+	// the grid reaches three bandwidths past the data to keep the kernel tails
+	g := newDensityGrid(xys, cols, rows, 0)
+	g.MinX, g.MaxX = g.MinX-3*hx, g.MaxX+3*hx
+	g.MinY, g.MaxY = g.MinY-3*hy, g.MaxY+3*hy
+	norm := 1 / (2 * math.Pi * hx * hy * float64(len(xys)))
+	for r := 0; r < rows; r++ {
+		y := g.Y(r)
+		for c := 0; c < cols; c++ {
+			x := g.X(c)
+			var sum float64
+			for _, p := range xys {
+				dx, dy := (x-p.X)/hx, (y-p.Y)/hy
+				sum += math.Exp(-(dx*dx + dy*dy) / 2)
+			}
+			g.Values[r*cols+c] = sum * norm
+		}
+	}
+	return g, nil
+}
+
+// Dims implements plotter.GridXYZ.
+func (g *DensityGrid) Dims() (c, r int) {
+	return g.Cols, g.Rows
+}
+
+// Z implements plotter.GridXYZ.
+func (g *DensityGrid) Z(c, r int) float64 {
+	return g.Values[r*g.Cols+c]
+}
+
+// X returns the centre of column c.
+func (g *DensityGrid) X(c int) float64 {
+	return g.MinX + (float64(c)+0.5)*(g.MaxX-g.MinX)/float64(g.Cols)
+}
+
+// Y returns the centre of row r.
+func (g *DensityGrid) Y(r int) float64 {
+	return g.MinY + (float64(r)+0.5)*(g.MaxY-g.MinY)/float64(g.Rows)
+}
+
+// Min returns the smallest density value.
+func (g *DensityGrid) Min() float64 {
+	m := math.Inf(1)
+	for _, v := range g.Values {
+		m = math.Min(m, v)
+	}
+	return m
+}
+
+// Max returns the largest density value.
+func (g *DensityGrid) Max() float64 {
+	m := math.Inf(-1)
+	for _, v := range g.Values {
+		m = math.Max(m, v)
+	}
+	return m
+}
+
+// DensityStyle selects how PlotDensity draws the grid, styles can be combined.
+type DensityStyle int
+
+const (
+	DensityHeatmap DensityStyle = 1 << iota
+	DensityContour
+)
+
+// densityLevels is the number of contour lines.
+const densityLevels = 6
+
+// WithDensityStyle sets the density rendering, DensityHeatmap by default.
+func WithDensityStyle(style DensityStyle) Option {
+	return func(o *Options) {
+		o.DensityStyle = style
+	}
+}
+
+// WithClusters overlays the density plot with a cluster scatter.
+func WithClusters(clstrsArray []plotter.XYs) Option {
+	return func(o *Options) {
+		o.Clusters = clstrsArray
+	}
+}
+
+// PlotDensity draws a density grid as a heat map and/or contour lines,
+// optionally overlaid with clusters, noise and centroids.
+func PlotDensity(path string, g plotter.GridXYZ, opts ...Option) error {
+	ch, err := DensityChart(g, opts...)
+	if err != nil {
+		return err
+	}
+	return ch.Save(path)
+}
+
+// DensityChart builds the density plot drawn by PlotDensity.
+func DensityChart(g plotter.GridXYZ, opts ...Option) (*Chart, error) {
+	o := newOptions(512, 512, opts)
+	if o.DensityStyle == 0 {
+		o.DensityStyle = DensityHeatmap
+	}
+	p := plot.New()
+
+	heat := plotter.NewHeatMap(g, palette.Heat(16, 1))
+	if o.DensityStyle&DensityHeatmap != 0 {
+		p.Add(heat)
+	}
+	if o.DensityStyle&DensityContour != 0 {
+		lo, hi := heat.Min, heat.Max
+		levels := make([]float64, densityLevels)
+		for i := range levels {
+			levels[i] = lo + (hi-lo)*float64(i+1)/float64(densityLevels+1)
+		}
+		p.Add(plotter.NewContour(g, levels, palette.Rainbow(densityLevels, palette.Blue, palette.Red, 1, 1, 1)))
+	}
+
+	if err := o.addClusters(p, o.Clusters); err != nil {
+		return nil, err
+	}
+	if err := o.addNoise(p); err != nil {
+		return nil, err
+	}
+	if err := o.addCentroids(p); err != nil {
+		return nil, err
+	}
+	if err := o.addExemplars(p); err != nil {
+		return nil, err
+	}
+
+	return newChart(p, o), nil
+}
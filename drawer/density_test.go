@@ -0,0 +1,76 @@
+package drawer
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+func randomXYs(n int, rng *rand.Rand) plotter.XYs {
+	xys := make(plotter.XYs, n)
+	for i := range xys {
+		xys[i] = plotter.XY{X: rng.Float64() * 10, Y: rng.Float64() * 10}
+	}
+	return xys
+}
+
+func TestKDEIntegral(t *testing.T) {
+	xys := randomXYs(200, rand.New(rand.NewSource(1)))
+	for _, bandwidth := range []float64{0.3, 0} {
+		g, err := KDE(xys, 120, 120, bandwidth)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cell := (g.MaxX - g.MinX) / float64(g.Cols) * (g.MaxY - g.MinY) / float64(g.Rows)
+		var integral float64
+		for _, v := range g.Values {
+			integral += v * cell
+		}
+		if math.Abs(integral-1) > 0.05 {
+			t.Errorf("bandwidth %g: density integrates to %g, want about 1", bandwidth, integral)
+		}
+	}
+}
+
+func TestHistogram2DCounts(t *testing.T) {
+	xys := randomXYs(500, rand.New(rand.NewSource(2)))
+	// the points on the edges of the range fall into the last cells
+	xys = append(xys, plotter.XY{X: 0, Y: 0}, plotter.XY{X: 10, Y: 10})
+	g, err := Histogram2D(xys, 7, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sum float64
+	for _, v := range g.Values {
+		sum += v
+	}
+	if sum != float64(len(xys)) {
+		t.Errorf("counts sum to %g, want %d", sum, len(xys))
+	}
+}
+
+func TestDensityInvalid(t *testing.T) {
+	if _, err := KDE(nil, 10, 10, 1); err == nil {
+		t.Error("KDE without points: want error")
+	}
+	if _, err := Histogram2D(plotter.XYs{{X: 1, Y: 1}}, 0, 10); err == nil {
+		t.Error("Histogram2D with no columns: want error")
+	}
+}
+
+func TestDensityChart(t *testing.T) {
+	xys := randomXYs(50, rand.New(rand.NewSource(3)))
+	g, err := KDE(xys, 20, 20, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, err := DensityChart(g, WithDensityStyle(DensityHeatmap|DensityContour), WithClusters([]plotter.XYs{xys}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := ch.Image().Bounds(); b.Dx() == 0 || b.Dy() == 0 {
+		t.Errorf("image is %v, want a non-empty one", b)
+	}
+}
@@ -3,126 +3,181 @@ package drawer
 import (
 	"fmt"
 	"image/color"
-	"log"
-	"math/rand/v2"
-	"os"
-	"strconv"
+	"math"
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
 )
 
-func PlotClasters(path string, clstrsArray []plotter.XYs) error {
-	f, err := os.Create(path)
+func PlotClasters(path string, clstrsArray []plotter.XYs, opts ...Option) error {
+	ch, err := ClastersChart(clstrsArray, opts...)
 	if err != nil {
-		return fmt.Errorf("could not create %s: %v", path, err)
+		return err
 	}
+	return ch.Save(path)
+}
+
+// ClastersChart builds the cluster scatter drawn by PlotClasters.
+func ClastersChart(clstrsArray []plotter.XYs, opts ...Option) (*Chart, error) {
+	o := newOptions(512, 512, opts)
+	p, err := clusterPlot(clstrsArray, o)
+	if err != nil {
+		return nil, err
+	}
+	return newChart(p, o), nil
+}
 
+// clusterPlot builds the plot drawn by PlotClasters.
+func clusterPlot(clstrsArray []plotter.XYs, o Options) (*plot.Plot, error) {
 	p := plot.New()
+	o.addRegions(p)
+
+	if err := o.addClusters(p, clstrsArray); err != nil {
+		return nil, err
+	}
+	if err := o.addNoise(p); err != nil {
+		return nil, err
+	}
+	if err := o.addCentroids(p); err != nil {
+		return nil, err
+	}
+	if err := o.addExemplars(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
 
-	for _, clst := range clstrsArray {
+// addClusters adds the outline and the scatter of every cluster.
+func (o Options) addClusters(p *plot.Plot, clstrsArray []plotter.XYs) error {
+	maxWeight, err := o.maxWeight(clstrsArray)
+	if err != nil {
+		return err
+	}
+	for i, clst := range clstrsArray {
+		if err := o.addOutline(p, i, clst); err != nil {
+			return err
+		}
 		sc, err := plotter.NewScatter(clst)
 		if err != nil {
 			return fmt.Errorf("could not create scatter: %v", err)
 		}
-		sc.GlyphStyle.Shape = draw.BoxGlyph{}
-
-		sc.Color = color.RGBA{
-			R: uint8(rand.Uint32() / 4),
-			G: uint8(rand.Uint32() / 4),
-			B: uint8(rand.Uint32() / 4),
-			A: 255,
+		sc.GlyphStyle.Shape = o.Palette.Shape(i)
+		sc.Color = o.Palette.Color(i)
+		if o.Weights != nil {
+			ws := o.Weights[i]
+			style := sc.GlyphStyle
+			sc.GlyphStyleFunc = func(j int) draw.GlyphStyle {
+				s := style
+				s.Radius = weightRadius(ws[j], maxWeight)
+				return s
+			}
 		}
 		p.Add(sc)
+		if o.Legend {
+			p.Legend.Add(o.legendName(i, fmt.Sprintf("cluster %d", i+1)), sc)
+		}
 	}
+	return nil
+}
 
-	wt, err := p.WriterTo(512, 512, "png")
-	if err != nil {
-		return fmt.Errorf("could not create writer: %v", err)
+// WithWeights scales the cluster glyphs by point weight: weights[i][j] is
+// the weight of the j-th point of cluster i, the glyph area is proportional
+// to it. Noise, centroids and exemplars keep the default size.
+func WithWeights(weights [][]float64) Option {
+	return func(o *Options) {
+		o.Weights = weights
 	}
-	_, err = wt.WriteTo(f)
-	if err != nil {
-		return fmt.Errorf("could not write to %s: %v", path, err)
+}
+
+// maxWeight checks that the weights match the clusters and returns the
+// largest one, 0 without weights.
+func (o Options) maxWeight(clstrsArray []plotter.XYs) (float64, error) {
+	if o.Weights == nil {
+		return 0, nil
+	}
+	if len(o.Weights) != len(clstrsArray) {
+		return 0, fmt.Errorf("got weights for %d clusters, want %d", len(o.Weights), len(clstrsArray))
 	}
+	var maxWeight float64
+	for i, ws := range o.Weights {
+		if len(ws) != len(clstrsArray[i]) {
+			return 0, fmt.Errorf("got %d weights for cluster %d, want %d", len(ws), i, len(clstrsArray[i]))
+		}
+		for _, w := range ws {
+			maxWeight = math.Max(maxWeight, w)
+		}
+	}
+	return maxWeight, nil
+}
 
-	if err := f.Close(); err != nil {
-		return fmt.Errorf("could not close %s: %v", path, err)
+// weightRadius returns a glyph radius whose area is proportional to the weight.
+func weightRadius(w, maxWeight float64) vg.Length {
+	const minRadius, maxRadius = vg.Length(1), vg.Length(8)
+	if maxWeight <= 0 || w <= 0 {
+		return minRadius
 	}
-	return nil
+	return max(minRadius, maxRadius*vg.Length(math.Sqrt(w/maxWeight)))
 }
 
-func PlotData(path string, xys plotter.XYs) error {
-	f, err := os.Create(path)
+// PlotData draws the points with a label next to each one, see WithLabels.
+func PlotData(path string, xys plotter.XYs, opts ...Option) error {
+	ch, err := DataChart(xys, opts...)
 	if err != nil {
-		return fmt.Errorf("could not create %s: %v", path, err)
+		return err
 	}
+	return ch.Save(path)
+}
 
+// DataChart builds the labelled scatter drawn by PlotData.
+func DataChart(xys plotter.XYs, opts ...Option) (*Chart, error) {
+	o := newOptions(512, 512, opts)
 	p := plot.New()
 
 	sp, err := plotter.NewScatter(xys)
 	if err != nil {
-		return fmt.Errorf("could not create scatter: %v", err)
+		return nil, fmt.Errorf("could not create scatter: %v", err)
 	}
 	sp.GlyphStyle.Shape = draw.CrossGlyph{}
 	sp.Color = color.RGBA{R: 255, A: 255}
 	p.Add(sp)
-
-	var lbs = make([]string, len(xys))
-	for i := range xys {
-		lbs[i] = strconv.Itoa(i)
-	}
-	labels, err := plotter.NewLabels(plotter.XYLabels{
-		XYs:    xys,
-		Labels: lbs,
-	})
-	if err != nil {
-		log.Fatalf("could not creates labels plotter: %+v", err)
+	if o.Legend {
+		p.Legend.Add(o.legendName(0, "points"), sp)
 	}
-	p.Add(labels)
 
-	wt, err := p.WriterTo(512, 512, "png")
-	if err != nil {
-		return fmt.Errorf("could not create writer: %v", err)
-	}
-	_, err = wt.WriteTo(f)
+	labels, err := newPointLabels(xys, o)
 	if err != nil {
-		return fmt.Errorf("could not write to %s: %v", path, err)
+		return nil, fmt.Errorf("could not create labels: %v", err)
 	}
+	p.Add(labels)
 
-	if err := f.Close(); err != nil {
-		return fmt.Errorf("could not close %s: %v", path, err)
-	}
-	return nil
+	return newChart(p, o), nil
 }
 
-func PlotPolygon(path string, xyer plotter.XYer) error {
-	f, err := os.Create(path)
+func PlotPolygon(path string, xyer plotter.XYer, opts ...Option) error {
+	ch, err := PolygonChart(xyer, opts...)
 	if err != nil {
-		return fmt.Errorf("could not create %s: %v", path, err)
+		return err
 	}
+	return ch.Save(path)
+}
 
+// PolygonChart builds the polygon drawn by PlotPolygon.
+func PolygonChart(xyer plotter.XYer, opts ...Option) (*Chart, error) {
+	o := newOptions(256, 256, opts)
 	p := plot.New()
 
 	s, err := plotter.NewPolygon(xyer)
 	if err != nil {
-		return fmt.Errorf("could not create scatter: %v", err)
+		return nil, fmt.Errorf("could not create scatter: %v", err)
 	}
 
 	s.Color = color.RGBA{R: 255, A: 255}
 	p.Add(s)
-
-	wt, err := p.WriterTo(256, 256, "png")
-	if err != nil {
-		return fmt.Errorf("could not create writer: %v", err)
-	}
-	_, err = wt.WriteTo(f)
-	if err != nil {
-		return fmt.Errorf("could not write to %s: %v", path, err)
+	if o.Legend {
+		p.Legend.Add(o.legendName(0, "polygon"), s)
 	}
 
-	if err := f.Close(); err != nil {
-		return fmt.Errorf("could not close %s: %v", path, err)
-	}
-	return nil
+	return newChart(p, o), nil
 }
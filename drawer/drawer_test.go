@@ -0,0 +1,35 @@
+package drawer
+
+import (
+	"testing"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+func TestWithWeights(t *testing.T) {
+	clusters := []plotter.XYs{{{X: 0, Y: 0}, {X: 1, Y: 1}}, {{X: 5, Y: 5}}}
+	ch, err := ClastersChart(clusters, WithWeights([][]float64{{1, 4}, {2}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch.Image()
+
+	for name, weights := range map[string][][]float64{
+		"missing cluster": {{1, 4}},
+		"missing point":   {{1}, {2}},
+	} {
+		if _, err := ClastersChart(clusters, WithWeights(weights)); err == nil {
+			t.Errorf("%s: want error", name)
+		}
+	}
+}
+
+func TestWeightRadius(t *testing.T) {
+	small, large := weightRadius(1, 4), weightRadius(4, 4)
+	if large != 2*small {
+		t.Errorf("radii for weights 1 and 4 are %v and %v, want the area proportional to the weight", small, large)
+	}
+	if r := weightRadius(0, 4); r <= 0 {
+		t.Errorf("radius for weight 0 is %v, want a visible glyph", r)
+	}
+}
@@ -0,0 +1,96 @@
+package drawer
+
+import (
+	"fmt"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/font"
+	"gonum.org/v1/plot/text"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// panelSize is the default size of one panel of a figure.
+const panelSize = 384
+
+// PlotFigure draws several clusterings side by side on one canvas, cols panels
+// per row. Every frame is a panel with its own title; the options title is
+// the title of the whole figure. All panels share the axis ranges, axis
+// labels are drawn on the bottom row and the left column only.
+func PlotFigure(path string, panels []Frame, cols int, opts ...Option) error {
+	ch, err := FigureChart(panels, cols, opts...)
+	if err != nil {
+		return err
+	}
+	return ch.Save(path)
+}
+
+// FigureChart builds the multi-panel figure drawn by PlotFigure.
+func FigureChart(panels []Frame, cols int, opts ...Option) (*Chart, error) {
+	if len(panels) == 0 {
+		return nil, fmt.Errorf("figure has no panels")
+	}
+	if cols <= 0 || cols > len(panels) {
+		cols = len(panels)
+	}
+	rows := (len(panels) + cols - 1) / cols
+	o := frameOptions(panels, newOptions(vg.Length(cols)*panelSize, vg.Length(rows)*panelSize, opts))
+
+	plots := make([][]*plot.Plot, rows)
+	for r := range plots {
+		plots[r] = make([]*plot.Plot, cols)
+	}
+	for i, panel := range panels {
+		r, c := i/cols, i%cols
+		po := o
+		po.Title = panel.Title
+		po.Centroids, po.Exemplars, po.Noise = panel.Centroids, panel.Exemplars, panel.Noise
+		if i+cols < len(panels) {
+			po.XLabel = ""
+		}
+		if c != 0 {
+			po.YLabel = ""
+		}
+		p, err := clusterPlot(panel.Clusters, po)
+		if err != nil {
+			return nil, fmt.Errorf("could not create panel %d: %v", i, err)
+		}
+		po.apply(p)
+		plots[r][c] = p
+	}
+
+	tiles := draw.Tiles{
+		Rows: rows, Cols: cols,
+		PadX: vg.Millimeter, PadY: vg.Millimeter,
+		PadTop: vg.Millimeter, PadBottom: vg.Millimeter,
+		PadLeft: vg.Millimeter, PadRight: vg.Millimeter,
+	}
+	return &Chart{o: o, draw: func(dc draw.Canvas) {
+		if o.Title != "" {
+			dc = figureTitle(dc, o.Title)
+		}
+		canvases := plot.Align(plots, tiles, dc)
+		for r, row := range plots {
+			for c, p := range row {
+				if p != nil {
+					p.Draw(canvases[r][c])
+				}
+			}
+		}
+	}}, nil
+}
+
+// figureTitle draws the title at the top of the canvas and returns the rest of it.
+func figureTitle(dc draw.Canvas, title string) draw.Canvas {
+	sty := text.Style{
+		Font:    font.From(plot.DefaultFont, 14),
+		XAlign:  draw.XCenter,
+		YAlign:  draw.YTop,
+		Handler: plot.DefaultTextHandler,
+	}
+	pad := vg.Millimeter
+	top := vg.Point{X: (dc.Min.X + dc.Max.X) / 2, Y: dc.Max.Y - pad}
+	dc.FillText(sty, top, title)
+	dc.Max.Y -= sty.Height(title) + 2*pad
+	return dc
+}
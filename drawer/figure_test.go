@@ -0,0 +1,52 @@
+package drawer
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/vgimg"
+)
+
+// blank reports whether every pixel of the rectangle is white.
+func blank(img image.Image, r image.Rectangle) bool {
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			if c := color.RGBAModel.Convert(img.At(x, y)).(color.RGBA); c.R != 0xff || c.G != 0xff || c.B != 0xff {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestFigureLayout(t *testing.T) {
+	frame := Frame{Clusters: []plotter.XYs{{{X: 0, Y: 0}, {X: 1, Y: 1}}, {{X: 5, Y: 5}}}}
+	ch, err := FigureChart([]Frame{frame, frame, frame}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img := ch.Image()
+	b := img.Bounds()
+	side := int(vg.Length(2 * panelSize).Dots(vgimg.DefaultDPI))
+	if b.Dx() != side || b.Dy() != side {
+		t.Fatalf("figure is %dx%d, want %dx%d", b.Dx(), b.Dy(), side, side)
+	}
+
+	// three panels fill a 2x2 grid row by row, the last tile stays empty
+	w, h := b.Dx()/2, b.Dy()/2
+	for i := 0; i < 4; i++ {
+		tile := image.Rect((i%2)*w, (i/2)*h, (i%2+1)*w, (i/2+1)*h).Inset(8).Add(b.Min)
+		if got, want := blank(img, tile), i == 3; got != want {
+			t.Errorf("tile %d: blank = %t, want %t", i, got, want)
+		}
+	}
+}
+
+func TestFigureNoPanels(t *testing.T) {
+	if _, err := FigureChart(nil, 2); err == nil {
+		t.Error("want error for a figure without panels")
+	}
+}
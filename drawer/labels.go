@@ -0,0 +1,167 @@
+package drawer
+
+import (
+	"fmt"
+	"strconv"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/font"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/text"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// labelGap is the distance between a point and its label.
+const labelGap = vg.Length(3)
+
+// labelOffsets are the candidate label positions around a point, tried in
+// order: right-top, left-top, right-bottom, left-bottom, right, left, top, bottom.
+// Each value is the label side (-1, 0, 1) along X and Y.
+var labelOffsets = [][2]int{
+	{1, 1}, {-1, 1}, {1, -1}, {-1, -1},
+	{1, 0}, {-1, 0}, {0, 1}, {0, -1},
+}
+
+// WithLabels sets the label text of every point, the i-th text is for the
+// i-th point. By default points are labelled with their index.
+func WithLabels(texts []string) Option {
+	return func(o *Options) {
+		o.Labels = texts
+	}
+}
+
+// WithMaxLabels limits the number of labels drawn, 0 means no limit.
+func WithMaxLabels(n int) Option {
+	return func(o *Options) {
+		o.MaxLabels = n
+	}
+}
+
+// pointLabels draws labels next to points. A label is put at the first
+// candidate position that neither overlaps other labels and points nor
+// leaves the canvas; labels that do not fit anywhere are dropped, so
+// dense areas are thinned out.
+type pointLabels struct {
+	xys    plotter.XYs
+	texts  []string
+	max    int
+	style  text.Style
+	radius vg.Length
+}
+
+// newPointLabels checks the texts and prepares labels for the points.
+func newPointLabels(xys plotter.XYs, o Options) (*pointLabels, error) {
+	texts := o.Labels
+	if texts == nil {
+		texts = make([]string, len(xys))
+		for i := range xys {
+			texts[i] = strconv.Itoa(i)
+		}
+	}
+	if len(texts) != len(xys) {
+		return nil, fmt.Errorf("got %d labels for %d points", len(texts), len(xys))
+	}
+	return &pointLabels{
+		xys:   xys,
+		texts: texts,
+		max:   o.MaxLabels,
+		style: text.Style{
+			Font:    font.From(plotter.DefaultFont, plotter.DefaultFontSize),
+			XAlign:  draw.XLeft,
+			YAlign:  draw.YBottom,
+			Handler: plot.DefaultTextHandler,
+		},
+		radius: plotter.DefaultGlyphStyle.Radius,
+	}, nil
+}
+
+// Plot implements plot.Plotter.
+func (l *pointLabels) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	pts := make([]vg.Point, len(l.xys))
+	for i, xy := range l.xys {
+		pts[i] = vg.Point{X: trX(xy.X), Y: trY(xy.Y)}
+	}
+	for i, r := range l.layout(c, pts) {
+		if r != nil {
+			c.FillText(l.style, r.Min, l.texts[i])
+		}
+	}
+}
+
+// layout returns the rectangle of every label on the canvas, nil for the
+// labels that are dropped.
+func (l *pointLabels) layout(c draw.Canvas, pts []vg.Point) []*vg.Rectangle {
+	var taken []vg.Rectangle
+	for _, pt := range pts {
+		taken = append(taken, vg.Rectangle{
+			Min: vg.Point{X: pt.X - l.radius, Y: pt.Y - l.radius},
+			Max: vg.Point{X: pt.X + l.radius, Y: pt.Y + l.radius},
+		})
+	}
+
+	rects := make([]*vg.Rectangle, len(pts))
+	drawn := 0
+	for i, txt := range l.texts {
+		if l.max > 0 && drawn >= l.max {
+			break
+		}
+		if txt == "" || !c.Contains(pts[i]) {
+			continue
+		}
+		w, h := l.style.Width(txt), l.style.Height(txt)
+		for _, off := range labelOffsets {
+			r := labelRect(pts[i], w, h, l.radius+labelGap, off)
+			if !containsRect(c.Rectangle, r) || overlapsAny(r, taken) {
+				continue
+			}
+			rects[i] = &r
+			taken = append(taken, r)
+			drawn++
+			break
+		}
+	}
+	return rects
+}
+
+// DataRange implements plot.DataRanger.
+func (l *pointLabels) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return plotter.XYRange(l.xys)
+}
+
+// labelRect returns the rectangle of a w x h label put on the off side of pt.
+func labelRect(pt vg.Point, w, h, gap vg.Length, off [2]int) vg.Rectangle {
+	var min vg.Point
+	switch off[0] {
+	case 1:
+		min.X = pt.X + gap
+	case -1:
+		min.X = pt.X - gap - w
+	default:
+		min.X = pt.X - w/2
+	}
+	switch off[1] {
+	case 1:
+		min.Y = pt.Y + gap
+	case -1:
+		min.Y = pt.Y - gap - h
+	default:
+		min.Y = pt.Y - h/2
+	}
+	return vg.Rectangle{Min: min, Max: vg.Point{X: min.X + w, Y: min.Y + h}}
+}
+
+func containsRect(outer, r vg.Rectangle) bool {
+	return r.Min.X >= outer.Min.X && r.Min.Y >= outer.Min.Y &&
+		r.Max.X <= outer.Max.X && r.Max.Y <= outer.Max.Y
+}
+
+func overlapsAny(r vg.Rectangle, rects []vg.Rectangle) bool {
+	for _, q := range rects {
+		if r.Min.X < q.Max.X && q.Min.X < r.Max.X && r.Min.Y < q.Max.Y && q.Min.Y < r.Max.Y {
+			return true
+		}
+	}
+	return false
+}
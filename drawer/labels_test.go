@@ -0,0 +1,76 @@
+package drawer
+
+import (
+	"testing"
+
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// labelLayout places the labels of points given in canvas coordinates on a
+// 200 x 200 canvas.
+func labelLayout(t *testing.T, pts []vg.Point, opts ...Option) []*vg.Rectangle {
+	t.Helper()
+	xys := make(plotter.XYs, len(pts))
+	for i, pt := range pts {
+		xys[i] = plotter.XY{X: float64(pt.X), Y: float64(pt.Y)}
+	}
+	l, err := newPointLabels(xys, newOptions(200, 200, opts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := draw.Canvas{Rectangle: vg.Rectangle{Max: vg.Point{X: 200, Y: 200}}}
+	return l.layout(c, pts)
+}
+
+func TestLabelsThinning(t *testing.T) {
+	// десять точек почти в одном месте: всем подписям места не хватит
+	var pts []vg.Point
+	for i := 0; i < 10; i++ {
+		pts = append(pts, vg.Point{X: 100 + vg.Length(i), Y: 100})
+	}
+	rects := labelLayout(t, pts)
+
+	var placed []vg.Rectangle
+	for _, r := range rects {
+		if r == nil {
+			continue
+		}
+		if overlapsAny(*r, placed) {
+			t.Errorf("label %v overlaps another one", *r)
+		}
+		placed = append(placed, *r)
+	}
+	if len(placed) == 0 || len(placed) == len(pts) {
+		t.Errorf("placed %d of %d labels, want the dense area thinned out", len(placed), len(pts))
+	}
+}
+
+func TestLabelsSparse(t *testing.T) {
+	pts := []vg.Point{{X: 20, Y: 20}, {X: 100, Y: 100}, {X: 160, Y: 160}}
+	for i, r := range labelLayout(t, pts) {
+		if r == nil {
+			t.Errorf("label %d is dropped, want every label of sparse points", i)
+		}
+	}
+}
+
+func TestMaxLabels(t *testing.T) {
+	pts := []vg.Point{{X: 20, Y: 20}, {X: 100, Y: 100}, {X: 160, Y: 160}, {X: 20, Y: 160}}
+	var n int
+	for _, r := range labelLayout(t, pts, WithMaxLabels(2)) {
+		if r != nil {
+			n++
+		}
+	}
+	if n != 2 {
+		t.Errorf("placed %d labels, want 2", n)
+	}
+}
+
+func TestLabelsCount(t *testing.T) {
+	if _, err := newPointLabels(plotter.XYs{{}, {}}, newOptions(200, 200, []Option{WithLabels([]string{"a"})})); err == nil {
+		t.Error("want error for fewer labels than points")
+	}
+}
@@ -0,0 +1,196 @@
+package drawer
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// Options describes how a plot is rendered and saved. All plot functions
+// take the same options and ignore the ones they have no use for:
+//
+//   - size, format, title, axis labels and ranges, grid and legend apply to
+//     every plot;
+//   - Palette, Noise, Centroids, Exemplars, Outline, Predict and Weights
+//     apply to the cluster plots: PlotClasters, PlotFigure and the
+//     animations, where every frame sets its own Noise, Centroids and
+//     Exemplars;
+//   - PlotDensity draws the same cluster overlays except Predict;
+//   - Labels and MaxLabels apply to PlotData only;
+//   - DensityStyle and Clusters apply to PlotDensity only.
+type Options struct {
+	Width, Height vg.Length
+	// Format is one of the gonum/plot formats: png, svg, pdf, eps, jpg, tif.
+	// When empty it is taken from the file extension, png by default.
+	Format string
+
+	Title          string
+	XLabel, YLabel string
+
+	XMin, XMax float64
+	YMin, YMax float64
+	xRange     bool
+	yRange     bool
+
+	Grid bool
+
+	Legend      bool
+	LegendNames []string
+
+	Palette Palette
+	// Noise holds points outside of any cluster, drawn in the palette noise colour.
+	Noise     plotter.XYs
+	Centroids plotter.XYs
+	// Exemplars are cluster points chosen as representatives, one per
+	// cluster in order, see WithExemplars.
+	Exemplars plotter.XYs
+	Outline   Outline
+	// Weights scale the cluster glyphs, see WithWeights.
+	Weights [][]float64
+	// Predict colours the background by cluster, see WithDecisionBoundary.
+	Predict func(x, y float64) int
+
+	// Labels and MaxLabels are used by PlotData only, see WithLabels.
+	Labels    []string
+	MaxLabels int
+
+	// DensityStyle and Clusters are used by PlotDensity only, the other
+	// plots take their clusters as an argument.
+	DensityStyle DensityStyle
+	Clusters     []plotter.XYs
+}
+
+// Option changes plot options.
+type Option func(*Options)
+
+// WithSize sets the image size.
+func WithSize(width, height vg.Length) Option {
+	return func(o *Options) {
+		o.Width, o.Height = width, height
+	}
+}
+
+// WithFormat sets the output format (png, svg, pdf, eps, ...).
+func WithFormat(format string) Option {
+	return func(o *Options) {
+		o.Format = strings.ToLower(format)
+	}
+}
+
+// WithTitle sets the plot title.
+func WithTitle(title string) Option {
+	return func(o *Options) {
+		o.Title = title
+	}
+}
+
+// WithAxisLabels sets the X and Y axis labels.
+func WithAxisLabels(x, y string) Option {
+	return func(o *Options) {
+		o.XLabel, o.YLabel = x, y
+	}
+}
+
+// WithXRange fixes the X axis range.
+func WithXRange(min, max float64) Option {
+	return func(o *Options) {
+		o.XMin, o.XMax, o.xRange = min, max, true
+	}
+}
+
+// WithYRange fixes the Y axis range.
+func WithYRange(min, max float64) Option {
+	return func(o *Options) {
+		o.YMin, o.YMax, o.yRange = min, max, true
+	}
+}
+
+// WithGrid draws grid lines.
+func WithGrid() Option {
+	return func(o *Options) {
+		o.Grid = true
+	}
+}
+
+// WithLegend adds a legend. Names are given per cluster in order, missing
+// names are generated as "cluster N".
+func WithLegend(names ...string) Option {
+	return func(o *Options) {
+		o.Legend = true
+		o.LegendNames = names
+	}
+}
+
+// WithNoise draws points that belong to no cluster in the noise colour.
+func WithNoise(noise plotter.XYs) Option {
+	return func(o *Options) {
+		o.Noise = noise
+	}
+}
+
+func newOptions(width, height vg.Length, opts []Option) Options {
+	o := Options{Width: width, Height: height, Palette: Tableau10}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	o.Palette = o.Palette.orDefault()
+	return o
+}
+
+// legendName returns the legend entry of the i-th plotter.
+func (o Options) legendName(i int, def string) string {
+	if i < len(o.LegendNames) {
+		return o.LegendNames[i]
+	}
+	return def
+}
+
+// addNoise draws the noise points if there are any.
+func (o Options) addNoise(p *plot.Plot) error {
+	if len(o.Noise) == 0 {
+		return nil
+	}
+	sc, err := plotter.NewScatter(o.Noise)
+	if err != nil {
+		return fmt.Errorf("could not create scatter: %v", err)
+	}
+	sc.GlyphStyle.Shape = noiseShape
+	sc.Color = o.Palette.Noise
+	p.Add(sc)
+	if o.Legend {
+		p.Legend.Add("noise", sc)
+	}
+	return nil
+}
+
+// apply sets title, labels, grid and axis ranges. It must be called after
+// all data plotters are added, otherwise the ranges are widened by the data.
+func (o Options) apply(p *plot.Plot) {
+	p.Title.Text = o.Title
+	p.X.Label.Text = o.XLabel
+	p.Y.Label.Text = o.YLabel
+	if o.Grid {
+		p.Add(plotter.NewGrid())
+	}
+	if o.xRange {
+		p.X.Min, p.X.Max = o.XMin, o.XMax
+	}
+	if o.yRange {
+		p.Y.Min, p.Y.Max = o.YMin, o.YMax
+	}
+}
+
+// format returns the output format for the path.
+func (o Options) format(path string) string {
+	if o.Format != "" {
+		return o.Format
+	}
+	if ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), "."); ext != "" {
+		return ext
+	}
+	return "png"
+}
@@ -0,0 +1,144 @@
+package drawer
+
+import (
+	"algos/geometry"
+	"fmt"
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Outline selects how every cluster is outlined.
+type Outline int
+
+const (
+	NoOutline Outline = iota
+	// HullOutline draws the convex hull of the cluster.
+	HullOutline
+	// EllipseOutline draws the 95% covariance ellipse of the cluster.
+	EllipseOutline
+)
+
+// ellipseStd is the ellipse size in standard deviations. It is the square
+// root of the 95% quantile of the chi-square distribution with 2 degrees of
+// freedom, so about 95% of bivariate normal points fall inside the ellipse.
+const ellipseStd = 2.4477
+
+// WithCentroids marks the cluster centres, one per cluster in order.
+func WithCentroids(centroids plotter.XYs) Option {
+	return func(o *Options) {
+		o.Centroids = centroids
+	}
+}
+
+// WithExemplars circles the exemplar of every cluster, one per cluster in
+// order, like the exemplars found by affinity propagation.
+func WithExemplars(exemplars plotter.XYs) Option {
+	return func(o *Options) {
+		o.Exemplars = exemplars
+	}
+}
+
+// WithOutline outlines every cluster with its convex hull or covariance ellipse.
+func WithOutline(outline Outline) Option {
+	return func(o *Options) {
+		o.Outline = outline
+	}
+}
+
+// addOutline draws the outline of the i-th cluster under its points.
+func (o Options) addOutline(p *plot.Plot, i int, xys plotter.XYs) error {
+	var polygon plotter.XYs
+	switch o.Outline {
+	case HullOutline:
+		polygon = geometry.ConvexHull(xys)
+	case EllipseOutline:
+		polygon = geometry.CovarianceEllipse(xys, ellipseStd, 64)
+	}
+	if len(polygon) < 3 {
+		return nil
+	}
+
+	poly, err := plotter.NewPolygon(polygon)
+	if err != nil {
+		return fmt.Errorf("could not create polygon: %v", err)
+	}
+	r, g, b, _ := o.Palette.Color(i).RGBA()
+	poly.Color = color.NRGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: 48}
+	poly.LineStyle.Color = o.Palette.Color(i)
+	p.Add(poly)
+	return nil
+}
+
+// addCentroids draws a ringed cross at every centroid in the cluster colour.
+func (o Options) addCentroids(p *plot.Plot) error {
+	if len(o.Centroids) == 0 {
+		return nil
+	}
+	sc, err := plotter.NewScatter(o.Centroids)
+	if err != nil {
+		return fmt.Errorf("could not create scatter: %v", err)
+	}
+	sc.GlyphStyle = draw.GlyphStyle{Color: color.Black, Radius: vg.Points(4), Shape: centroidGlyph{}}
+	sc.GlyphStyleFunc = func(i int) draw.GlyphStyle {
+		return draw.GlyphStyle{
+			Color:  o.Palette.Color(i),
+			Radius: vg.Points(7),
+			Shape:  centroidGlyph{},
+		}
+	}
+	p.Add(sc)
+	if o.Legend {
+		p.Legend.Add("centroid", sc)
+	}
+	return nil
+}
+
+// addExemplars draws a thick ring in the cluster colour around every exemplar.
+func (o Options) addExemplars(p *plot.Plot) error {
+	if len(o.Exemplars) == 0 {
+		return nil
+	}
+	sc, err := plotter.NewScatter(o.Exemplars)
+	if err != nil {
+		return fmt.Errorf("could not create scatter: %v", err)
+	}
+	sc.GlyphStyle = draw.GlyphStyle{Color: color.Black, Radius: vg.Points(4), Shape: exemplarGlyph{}}
+	sc.GlyphStyleFunc = func(i int) draw.GlyphStyle {
+		return draw.GlyphStyle{
+			Color:  o.Palette.Color(i),
+			Radius: vg.Points(6),
+			Shape:  exemplarGlyph{},
+		}
+	}
+	p.Add(sc)
+	if o.Legend {
+		p.Legend.Add("exemplar", sc)
+	}
+	return nil
+}
+
+// exemplarGlyph is a ring drawn with a thick line, so the point inside
+// stays visible.
+type exemplarGlyph struct{}
+
+func (exemplarGlyph) DrawGlyph(c *draw.Canvas, sty draw.GlyphStyle, pt vg.Point) {
+	c.SetLineStyle(draw.LineStyle{Color: sty.Color, Width: vg.Points(2)})
+	var path vg.Path
+	path.Move(vg.Point{X: pt.X + sty.Radius, Y: pt.Y})
+	path.Arc(pt, sty.Radius, 0, 2*math.Pi)
+	path.Close()
+	c.Stroke(path)
+}
+
+// centroidGlyph is a ring with a cross inside.
+type centroidGlyph struct{}
+
+func (centroidGlyph) DrawGlyph(c *draw.Canvas, sty draw.GlyphStyle, pt vg.Point) {
+	draw.RingGlyph{}.DrawGlyph(c, sty, pt)
+	draw.CrossGlyph{}.DrawGlyph(c, sty, pt)
+}
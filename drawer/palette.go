@@ -0,0 +1,114 @@
+package drawer
+
+import (
+	"image/color"
+
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Palette is a qualitative colour palette. Cluster i always gets the same
+// colour; when clusters outnumber colours the glyph shape changes instead.
+type Palette struct {
+	Name   string
+	Colors []color.Color
+	Noise  color.Color
+}
+
+var (
+	// Tableau10 is the Tableau 10 palette.
+	Tableau10 = Palette{
+		Name: "tableau10",
+		Colors: []color.Color{
+			rgb(0x4e79a7), rgb(0xf28e2b), rgb(0xe15759), rgb(0x76b7b2), rgb(0x59a14f),
+			rgb(0xedc948), rgb(0xb07aa1), rgb(0xff9da7), rgb(0x9c755f), rgb(0xbab0ac),
+		},
+		Noise: rgb(0x7f7f7f),
+	}
+
+	// Set1 is the ColorBrewer Set1 palette.
+	Set1 = Palette{
+		Name: "set1",
+		Colors: []color.Color{
+			rgb(0xe41a1c), rgb(0x377eb8), rgb(0x4daf4a), rgb(0x984ea3), rgb(0xff7f00),
+			rgb(0xffff33), rgb(0xa65628), rgb(0xf781bf), rgb(0x999999),
+		},
+		Noise: rgb(0xcccccc),
+	}
+
+	// Set3 is the ColorBrewer Set3 palette.
+	Set3 = Palette{
+		Name: "set3",
+		Colors: []color.Color{
+			rgb(0x8dd3c7), rgb(0xffffb3), rgb(0xbebada), rgb(0xfb8072), rgb(0x80b1d3), rgb(0xfdb462),
+			rgb(0xb3de69), rgb(0xfccde5), rgb(0xd9d9d9), rgb(0xbc80bd), rgb(0xccebc5), rgb(0xffed6f),
+		},
+		Noise: rgb(0x525252),
+	}
+
+	// OkabeIto is the colour-blind safe Okabe–Ito palette.
+	OkabeIto = Palette{
+		Name: "okabe-ito",
+		Colors: []color.Color{
+			rgb(0xe69f00), rgb(0x56b4e9), rgb(0x009e73), rgb(0xf0e442),
+			rgb(0x0072b2), rgb(0xd55e00), rgb(0xcc79a7), rgb(0x000000),
+		},
+		Noise: rgb(0xbbbbbb),
+	}
+)
+
+// Palettes lists the built-in palettes by name.
+var Palettes = map[string]Palette{
+	Tableau10.Name: Tableau10,
+	Set1.Name:      Set1,
+	Set3.Name:      Set3,
+	OkabeIto.Name:  OkabeIto,
+}
+
+// shapes are cycled when clusters outnumber the palette colours.
+var shapes = []draw.GlyphDrawer{
+	draw.BoxGlyph{},
+	draw.CircleGlyph{},
+	draw.TriangleGlyph{},
+	draw.PyramidGlyph{},
+	draw.RingGlyph{},
+	draw.SquareGlyph{},
+	draw.PlusGlyph{},
+}
+
+// noiseShape is the glyph of noise points.
+var noiseShape = draw.CrossGlyph{}
+
+// Color returns the colour of the i-th cluster.
+func (p Palette) Color(i int) color.Color {
+	return p.Colors[i%len(p.Colors)]
+}
+
+// Shape returns the glyph shape of the i-th cluster: the first len(Colors)
+// clusters are boxes, the next ones circles and so on.
+func (p Palette) Shape(i int) draw.GlyphDrawer {
+	return shapes[(i/len(p.Colors))%len(shapes)]
+}
+
+// WithPalette sets the cluster colour palette, Tableau10 by default.
+// Missing colours are taken from Tableau10.
+func WithPalette(p Palette) Option {
+	return func(o *Options) {
+		o.Palette = p
+	}
+}
+
+// orDefault fills an empty colour list or noise colour from Tableau10,
+// so that Color and Shape never divide by zero.
+func (p Palette) orDefault() Palette {
+	if len(p.Colors) == 0 {
+		p.Colors = Tableau10.Colors
+	}
+	if p.Noise == nil {
+		p.Noise = Tableau10.Noise
+	}
+	return p
+}
+
+func rgb(hex uint32) color.RGBA {
+	return color.RGBA{R: uint8(hex >> 16), G: uint8(hex >> 8), B: uint8(hex), A: 255}
+}
@@ -0,0 +1,26 @@
+package drawer
+
+import (
+	"image/color"
+	"testing"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+func TestEmptyPaletteFallsBack(t *testing.T) {
+	clusters := []plotter.XYs{{{X: 0, Y: 0}}, {{X: 1, Y: 1}}}
+	if _, err := ClastersChart(clusters, WithPalette(Palette{})); err != nil {
+		t.Fatal(err)
+	}
+
+	o := newOptions(1, 1, []Option{WithPalette(Palette{Name: "custom"})})
+	if o.Palette.Color(3) != Tableau10.Color(3) || o.Palette.Noise != Tableau10.Noise {
+		t.Error("empty palette does not fall back to Tableau10")
+	}
+
+	red := color.RGBA{R: 255, A: 255}
+	o = newOptions(1, 1, []Option{WithPalette(Palette{Colors: []color.Color{red}})})
+	if o.Palette.Color(5) != red {
+		t.Error("palette colours were replaced")
+	}
+}
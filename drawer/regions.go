@@ -0,0 +1,75 @@
+package drawer
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// regionResolution is the side of the background raster in pixels.
+const regionResolution = 256
+
+// WithDecisionBoundary fills the plot background with the colour of the
+// cluster predict returns for every location. Negative results stay blank.
+func WithDecisionBoundary(predict func(x, y float64) int) Option {
+	return func(o *Options) {
+		o.Predict = predict
+	}
+}
+
+// WithVoronoi fills the plot background with the Voronoi regions of the
+// centroids, that is every location gets the colour of its nearest centroid.
+func WithVoronoi(centroids plotter.XYs) Option {
+	return WithDecisionBoundary(func(x, y float64) int {
+		nearest, best := -1, math.MaxFloat64
+		for i, c := range centroids {
+			if d := (c.X-x)*(c.X-x) + (c.Y-y)*(c.Y-y); d < best {
+				nearest, best = i, d
+			}
+		}
+		return nearest
+	})
+}
+
+// addRegions adds the decision boundary under everything else.
+func (o Options) addRegions(p *plot.Plot) {
+	if o.Predict == nil {
+		return
+	}
+	p.Add(regions{predict: o.Predict, palette: o.Palette})
+}
+
+// regions rasterises the decision boundary over the final axis ranges, so it
+// always fills the whole data area and does not change the ranges itself.
+type regions struct {
+	predict func(x, y float64) int
+	palette Palette
+}
+
+// Plot implements the plot.Plotter interface.
+func (r regions) Plot(c draw.Canvas, p *plot.Plot) {
+	c.DrawImage(c.Rectangle, r.raster(p.X.Min, p.X.Max, p.Y.Min, p.Y.Max))
+}
+
+// raster samples predict at the pixel centres of the given data area, the
+// first image row is at the top.
+func (r regions) raster(xmin, xmax, ymin, ymax float64) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, regionResolution, regionResolution))
+	for py := 0; py < regionResolution; py++ {
+		y := ymax - (float64(py)+0.5)/regionResolution*(ymax-ymin)
+		for px := 0; px < regionResolution; px++ {
+			x := xmin + (float64(px)+0.5)/regionResolution*(xmax-xmin)
+			k := r.predict(x, y)
+			if k < 0 {
+				continue
+			}
+			cr, cg, cb, _ := r.palette.Color(k).RGBA()
+			img.SetNRGBA(px, py, color.NRGBA{R: uint8(cr >> 8), G: uint8(cg >> 8), B: uint8(cb >> 8), A: 64})
+		}
+	}
+	return img
+}
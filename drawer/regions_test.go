@@ -0,0 +1,51 @@
+package drawer
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+func TestVoronoiRaster(t *testing.T) {
+	centroids := plotter.XYs{{X: 1, Y: 1}, {X: 8, Y: 2}, {X: 4, Y: 9}}
+	o := newOptions(512, 512, []Option{WithVoronoi(centroids)})
+	r := regions{predict: o.Predict, palette: o.Palette}
+	const xmin, xmax, ymin, ymax = 0.0, 10.0, -5.0, 15.0
+	img := r.raster(xmin, xmax, ymin, ymax)
+
+	for py := 0; py < regionResolution; py += 7 {
+		for px := 0; px < regionResolution; px += 7 {
+			x := xmin + (float64(px)+0.5)/regionResolution*(xmax-xmin)
+			y := ymax - (float64(py)+0.5)/regionResolution*(ymax-ymin)
+			nearest, best := -1, math.Inf(1)
+			for i, c := range centroids {
+				if d := math.Hypot(c.X-x, c.Y-y); d < best {
+					nearest, best = i, d
+				}
+			}
+			cr, cg, cb, _ := o.Palette.Color(nearest).RGBA()
+			got := img.NRGBAAt(px, py)
+			if got.R != uint8(cr>>8) || got.G != uint8(cg>>8) || got.B != uint8(cb>>8) || got.A == 0 {
+				t.Fatalf("pixel (%d, %d) at (%.2f, %.2f) is %v, want the colour of centroid %d", px, py, x, y, got, nearest)
+			}
+		}
+	}
+}
+
+func TestDecisionBoundaryBlank(t *testing.T) {
+	// отрицательный ответ оставляет левую половину прозрачной
+	r := regions{predict: func(x, y float64) int {
+		if x < 0 {
+			return -1
+		}
+		return 0
+	}, palette: Tableau10}
+	img := r.raster(-1, 1, 0, 1)
+	if a := img.NRGBAAt(0, 0).A; a != 0 {
+		t.Errorf("left pixel alpha = %d, want 0", a)
+	}
+	if a := img.NRGBAAt(regionResolution-1, 0).A; a == 0 {
+		t.Error("right pixel is blank, want the cluster colour")
+	}
+}
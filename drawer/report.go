@@ -0,0 +1,265 @@
+package drawer
+
+import (
+	"fmt"
+	"html/template"
+	"image/color"
+	"io"
+	"math"
+	"os"
+	"sort"
+)
+
+// PointKind is the DBSCAN role of a point, 0 means unknown.
+type PointKind int
+
+const (
+	KindCore PointKind = iota + 1
+	KindBorder
+	KindNoise
+)
+
+func (k PointKind) String() string {
+	switch k {
+	case KindCore:
+		return "core"
+	case KindBorder:
+		return "border"
+	case KindNoise:
+		return "noise"
+	}
+	return ""
+}
+
+// ReportPoint is one point of a cluster report. Cluster starts at 1, 0 is noise.
+type ReportPoint struct {
+	ID      int
+	X, Y    float64
+	Cluster int
+	Kind    PointKind
+}
+
+// Report is a self-contained HTML page with an SVG scatter of a clustering,
+// per-point tooltips, cluster toggles and a summary table.
+type Report struct {
+	Title   string
+	Points  []ReportPoint
+	Palette Palette
+	// Names holds optional cluster names, the i-th name is for cluster i+1.
+	Names []string
+}
+
+const (
+	reportSize   = 640.0
+	reportMargin = 40.0
+)
+
+type reportCircle struct {
+	X, Y    float64
+	Tooltip string
+	Kind    string
+}
+
+type reportCluster struct {
+	ID       int
+	Name     string
+	Color    string
+	Count    int
+	Core     int
+	Border   int
+	CenterX  float64
+	CenterY  float64
+	Circles  []reportCircle
+	Noise    bool
+	Checkbox string
+}
+
+type reportPage struct {
+	Title    string
+	Size     float64
+	Margin   float64
+	Inner    float64
+	MinX     float64
+	MaxX     float64
+	MinY     float64
+	MaxY     float64
+	Clusters []*reportCluster
+	Total    int
+}
+
+// WriteReport writes the HTML report to w.
+func WriteReport(w io.Writer, r Report) error {
+	if len(r.Points) == 0 {
+		return fmt.Errorf("report has no points")
+	}
+	for _, p := range r.Points {
+		if p.Cluster < 0 {
+			return fmt.Errorf("point %d has negative cluster %d", p.ID, p.Cluster)
+		}
+		if p.Kind < 0 || p.Kind > KindNoise {
+			return fmt.Errorf("point %d has unknown kind %d", p.ID, int(p.Kind))
+		}
+	}
+	r.Palette = r.Palette.orDefault()
+
+	minX, maxX := math.Inf(1), math.Inf(-1)
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	for _, p := range r.Points {
+		minX, maxX = math.Min(minX, p.X), math.Max(maxX, p.X)
+		minY, maxY = math.Min(minY, p.Y), math.Max(maxY, p.Y)
+	}
+	inner := reportSize - 2*reportMargin
+	scale := func(v, lo, hi float64) float64 {
+		if hi == lo {
+			return inner / 2
+		}
+		return (v - lo) / (hi - lo) * inner
+	}
+
+	byID := make(map[int]*reportCluster)
+	for _, p := range r.Points {
+		c, found := byID[p.Cluster]
+		if !found {
+			c = &reportCluster{ID: p.Cluster, Noise: p.Cluster == 0}
+			switch {
+			case c.Noise:
+				c.Name = "noise"
+				c.Color = hexColor(r.Palette.Noise)
+			case p.Cluster-1 < len(r.Names):
+				c.Name = r.Names[p.Cluster-1]
+				c.Color = hexColor(r.Palette.Color(p.Cluster - 1))
+			default:
+				c.Name = fmt.Sprintf("cluster %d", p.Cluster)
+				c.Color = hexColor(r.Palette.Color(p.Cluster - 1))
+			}
+			c.Checkbox = fmt.Sprintf("cluster-%d", p.Cluster)
+			byID[p.Cluster] = c
+		}
+
+		kind := p.Kind
+		if kind == 0 && p.Cluster == 0 {
+			kind = KindNoise
+		}
+		c.Count++
+		switch kind {
+		case KindCore:
+			c.Core++
+		case KindBorder:
+			c.Border++
+		}
+		c.CenterX += p.X
+		c.CenterY += p.Y
+
+		tooltip := fmt.Sprintf("id %d\n(%g, %g)\n%s", p.ID, p.X, p.Y, c.Name)
+		if kind != 0 {
+			tooltip += "\n" + kind.String()
+		}
+		c.Circles = append(c.Circles, reportCircle{
+			X:       reportMargin + scale(p.X, minX, maxX),
+			Y:       reportMargin + inner - scale(p.Y, minY, maxY),
+			Tooltip: tooltip,
+			Kind:    kind.String(),
+		})
+	}
+
+	page := reportPage{
+		Title:  r.Title,
+		Size:   reportSize,
+		Margin: reportMargin,
+		Inner:  inner,
+		MinX:   minX,
+		MaxX:   maxX,
+		MinY:   minY,
+		MaxY:   maxY,
+		Total:  len(r.Points),
+	}
+	for _, c := range byID {
+		c.CenterX /= float64(c.Count)
+		c.CenterY /= float64(c.Count)
+		page.Clusters = append(page.Clusters, c)
+	}
+	// шум в конце списка
+	sort.Slice(page.Clusters, func(i, j int) bool {
+		a, b := page.Clusters[i], page.Clusters[j]
+		if a.Noise != b.Noise {
+			return b.Noise
+		}
+		return a.ID < b.ID
+	})
+
+	return reportTemplate.Execute(w, page)
+}
+
+// SaveReport writes the HTML report to the file at path.
+func SaveReport(path string, r Report) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create %s: %v", path, err)
+	}
+	if err := WriteReport(f, r); err != nil {
+		f.Close()
+		return fmt.Errorf("could not write to %s: %v", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("could not close %s: %v", path, err)
+	}
+	return nil
+}
+
+func hexColor(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 20px; }
+.layout { display: flex; gap: 24px; align-items: flex-start; }
+svg { border: 1px solid #ccc; background: #fff; }
+circle.border { stroke: #000; stroke-width: 0.5; }
+circle.noise { fill-opacity: 0.6; }
+circle:hover { stroke: #000; stroke-width: 2; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: right; }
+td.name { text-align: left; }
+.swatch { display: inline-block; width: 12px; height: 12px; margin-right: 6px; vertical-align: middle; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div class="layout">
+<svg xmlns="http://www.w3.org/2000/svg" width="{{.Size}}" height="{{.Size}}" viewBox="0 0 {{.Size}} {{.Size}}">
+<rect x="{{.Margin}}" y="{{.Margin}}" width="{{.Inner}}" height="{{.Inner}}" fill="none" stroke="#999"/>
+<text x="{{.Margin}}" y="{{.Size}}" dy="-20" font-size="11">{{printf "%g" .MinX}}</text>
+<text x="{{.Size}}" y="{{.Size}}" dx="-40" dy="-20" font-size="11" text-anchor="end">{{printf "%g" .MaxX}}</text>
+<text x="4" y="{{.Size}}" dy="-40" font-size="11">{{printf "%g" .MinY}}</text>
+<text x="4" y="{{.Margin}}" font-size="11">{{printf "%g" .MaxY}}</text>
+{{range .Clusters}}<g id="{{.Checkbox}}" fill="{{.Color}}">
+{{range .Circles}}<circle class="{{.Kind}}" cx="{{printf "%.2f" .X}}" cy="{{printf "%.2f" .Y}}" r="{{if eq .Kind "core"}}5{{else}}4{{end}}"><title>{{.Tooltip}}</title></circle>
+{{end}}</g>
+{{end}}</svg>
+<div>
+<table>
+<tr><th>show</th><th>cluster</th><th>points</th><th>core</th><th>border</th><th>center x</th><th>center y</th></tr>
+{{range .Clusters}}<tr>
+<td><input type="checkbox" checked data-target="{{.Checkbox}}" onchange="toggle(this)"></td>
+<td class="name"><span class="swatch" style="background: {{.Color}}"></span>{{.Name}}</td>
+<td>{{.Count}}</td><td>{{.Core}}</td><td>{{.Border}}</td>
+<td>{{printf "%.2f" .CenterX}}</td><td>{{printf "%.2f" .CenterY}}</td>
+</tr>
+{{end}}<tr><td></td><td class="name">total</td><td>{{.Total}}</td><td colspan="4"></td></tr>
+</table>
+</div>
+</div>
+<script>
+function toggle(box) {
+  document.getElementById(box.dataset.target).style.display = box.checked ? "" : "none";
+}
+</script>
+</body>
+</html>
+`))
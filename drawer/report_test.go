@@ -0,0 +1,41 @@
+package drawer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteReport(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteReport(&buf, Report{
+		Title: "test",
+		Points: []ReportPoint{
+			{ID: 1, X: 0, Y: 0, Cluster: 1, Kind: KindCore},
+			{ID: 2, X: 1, Y: 1, Cluster: 1, Kind: KindBorder},
+			{ID: 3, X: 5, Y: 5},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"cluster 1", `class="core"`, `class="border"`, `class="noise"`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("report has no %q", want)
+		}
+	}
+}
+
+func TestWriteReportInvalid(t *testing.T) {
+	for name, p := range map[string]ReportPoint{
+		"negative cluster": {ID: 1, Cluster: -1},
+		"unknown kind":     {ID: 1, Cluster: 1, Kind: KindNoise + 1},
+	} {
+		if err := WriteReport(&bytes.Buffer{}, Report{Points: []ReportPoint{p}}); err == nil {
+			t.Errorf("%s: want error", name)
+		}
+	}
+	if err := WriteReport(&bytes.Buffer{}, Report{}); err == nil {
+		t.Error("empty report: want error")
+	}
+}
@@ -0,0 +1,95 @@
+package geometry
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+// AlphaShape строит альфа-форму точек: из триангуляции Делоне остаются
+// треугольники с радиусом описанной окружности не больше radius, а их
+// внешние ребра собираются в замкнутые контуры. Внешние контуры идут против
+// часовой стрелки, дыры - по часовой; контуры отсортированы по убыванию площади.
+func AlphaShape(pts plotter.XYs, radius float64) []plotter.XYs {
+	unique := slices.Clone(pts)
+	slices.SortFunc(unique, func(a, b plotter.XY) int {
+		if a.X != b.X {
+			return cmp.Compare(a.X, b.X)
+		}
+		return cmp.Compare(a.Y, b.Y)
+	})
+	unique = slices.Compact(unique)
+
+	// ребро граничное, если встречается только в одном треугольнике
+	edges := make(map[[2]int]bool)
+	for _, t := range Delaunay(unique) {
+		if Circumradius(unique, t) > radius {
+			continue
+		}
+		for k := 0; k < 3; k++ {
+			a, b := t[k], t[(k+1)%3]
+			if edges[[2]int{b, a}] {
+				delete(edges, [2]int{b, a})
+			} else {
+				edges[[2]int{a, b}] = true
+			}
+		}
+	}
+
+	next := make(map[int][]int)
+	for edge := range edges {
+		next[edge[0]] = append(next[edge[0]], edge[1])
+	}
+	for _, to := range next {
+		slices.Sort(to)
+	}
+
+	var rings []plotter.XYs
+	for _, start := range sortedKeys(next) {
+		for len(next[start]) > 0 {
+			var ring plotter.XYs
+			for v := start; ; {
+				ring = append(ring, unique[v])
+				to := next[v]
+				if len(to) == 0 {
+					break
+				}
+				next[v] = to[1:]
+				v = to[0]
+				if v == start {
+					break
+				}
+			}
+			if len(ring) >= 3 {
+				rings = append(rings, ring)
+			}
+		}
+	}
+
+	slices.SortStableFunc(rings, func(a, b plotter.XYs) int {
+		return cmp.Compare(math.Abs(SignedArea(b)), math.Abs(SignedArea(a)))
+	})
+	return rings
+}
+
+// ConcaveHull возвращает внешний контур альфа-формы наибольшей площади.
+// Если при таком radius треугольников не осталось, возвращается выпуклая оболочка.
+func ConcaveHull(pts plotter.XYs, radius float64) plotter.XYs {
+	for _, ring := range AlphaShape(pts, radius) {
+		if SignedArea(ring) > 0 {
+			return ring
+		}
+	}
+	return ConvexHull(pts)
+}
+
+func sortedKeys[V any](m map[int]V) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,44 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+// lShape возвращает узлы сетки с шагом 0.5 в L-образной области
+// [0,4]x[0,1] и [0,1]x[0,4] площадью 7. Альфа-форма добавляет к ней
+// треугольник во внутреннем углу площадью 1/8.
+func lShape() plotter.XYs {
+	var pts plotter.XYs
+	for i := 0; i <= 8; i++ {
+		for j := 0; j <= 8; j++ {
+			x, y := float64(i)/2, float64(j)/2
+			if x <= 1 || y <= 1 {
+				pts = append(pts, plotter.XY{X: x, Y: y})
+			}
+		}
+	}
+	return pts
+}
+
+func TestConcaveHullLShape(t *testing.T) {
+	pts := lShape()
+	hull := Area(ConvexHull(pts))
+	if math.Abs(hull-11.5) > 1e-9 {
+		t.Errorf("hull area = %g, want 11.5", hull)
+	}
+	concave := ConcaveHull(pts, 0.4)
+	if got := Area(concave); got >= hull || math.Abs(got-7.125) > 1e-9 {
+		t.Errorf("concave area = %g, want 7.125, below the hull area %g", got, hull)
+	}
+	if SignedArea(concave) <= 0 {
+		t.Error("concave hull is not counter-clockwise")
+	}
+
+	// при большом радиусе альфа-форма совпадает с выпуклой оболочкой
+	if got := Area(ConcaveHull(pts, 100)); math.Abs(got-hull) > 1e-9 {
+		t.Errorf("concave area with a large radius = %g, want the hull area %g", got, hull)
+	}
+}
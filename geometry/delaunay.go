@@ -0,0 +1,103 @@
+package geometry
+
+import (
+	"math"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+// Triangle - треугольник из индексов вершин, обход против часовой стрелки.
+type Triangle [3]int
+
+// circle - описанная окружность треугольника.
+type circle struct {
+	x, y, r2 float64
+}
+
+// Delaunay строит триангуляцию Делоне алгоритмом Боуэра-Ватсона.
+// Точки должны быть различными; индексы в треугольниках указывают на pts.
+func Delaunay(pts plotter.XYs) []Triangle {
+	if len(pts) < 3 {
+		return nil
+	}
+
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range pts {
+		minX, maxX = math.Min(minX, p.X), math.Max(maxX, p.X)
+		minY, maxY = math.Min(minY, p.Y), math.Max(maxY, p.Y)
+	}
+	size := math.Max(maxX-minX, maxY-minY)
+	if size == 0 {
+		size = 1
+	}
+	midX, midY := (minX+maxX)/2, (minY+maxY)/2
+
+	// вершины супертреугольника добавляются в конец и удаляются в конце
+	n := len(pts)
+	all := append(pts[:n:n],
+		plotter.XY{X: midX - 1e4*size, Y: midY - 1e4*size},
+		plotter.XY{X: midX + 1e4*size, Y: midY - 1e4*size},
+		plotter.XY{X: midX, Y: midY + 1e4*size},
+	)
+
+	type entry struct {
+		t Triangle
+		c circle
+	}
+	triangles := []entry{{Triangle{n, n + 1, n + 2}, circumcircle(all, Triangle{n, n + 1, n + 2})}}
+
+	for i := 0; i < n; i++ {
+		p := all[i]
+		edges := make(map[[2]int]int)
+		kept := triangles[:0]
+		for _, e := range triangles {
+			dx, dy := p.X-e.c.x, p.Y-e.c.y
+			if dx*dx+dy*dy < e.c.r2 {
+				for k := 0; k < 3; k++ {
+					a, b := e.t[k], e.t[(k+1)%3]
+					// общее ребро двух плохих треугольников обходится в разные стороны
+					if _, found := edges[[2]int{b, a}]; found {
+						delete(edges, [2]int{b, a})
+					} else {
+						edges[[2]int{a, b}] = 1
+					}
+				}
+				continue
+			}
+			kept = append(kept, e)
+		}
+		triangles = kept
+		for edge := range edges {
+			t := Triangle{edge[0], edge[1], i}
+			triangles = append(triangles, entry{t, circumcircle(all, t)})
+		}
+	}
+
+	var result []Triangle
+	for _, e := range triangles {
+		if e.t[0] < n && e.t[1] < n && e.t[2] < n {
+			result = append(result, e.t)
+		}
+	}
+	return result
+}
+
+// Circumradius возвращает радиус описанной окружности треугольника.
+func Circumradius(pts plotter.XYs, t Triangle) float64 {
+	return math.Sqrt(circumcircle(pts, t).r2)
+}
+
+func circumcircle(pts plotter.XYs, t Triangle) circle {
+	a, b, c := pts[t[0]], pts[t[1]], pts[t[2]]
+	d := 2 * (a.X*(b.Y-c.Y) + b.X*(c.Y-a.Y) + c.X*(a.Y-b.Y))
+	if d == 0 {
+		return circle{r2: math.Inf(1)}
+	}
+	a2 := a.X*a.X + a.Y*a.Y
+	b2 := b.X*b.X + b.Y*b.Y
+	c2 := c.X*c.X + c.Y*c.Y
+	x := (a2*(b.Y-c.Y) + b2*(c.Y-a.Y) + c2*(a.Y-b.Y)) / d
+	y := (a2*(c.X-b.X) + b2*(a.X-c.X) + c2*(b.X-a.X)) / d
+	return circle{x: x, y: y, r2: (a.X-x)*(a.X-x) + (a.Y-y)*(a.Y-y)}
+}
@@ -0,0 +1,44 @@
+package geometry
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/plot/plotter"
+)
+
+// CovarianceEllipse возвращает многоугольник из segments вершин,
+// аппроксимирующий эллипс ковариации точек радиусом nstd стандартных
+// отклонений. Для менее чем двух точек возвращается nil.
+func CovarianceEllipse(pts plotter.XYs, nstd float64, segments int) plotter.XYs {
+	if len(pts) < 2 {
+		return nil
+	}
+	xs := make([]float64, len(pts))
+	ys := make([]float64, len(pts))
+	for i, p := range pts {
+		xs[i], ys[i] = p.X, p.Y
+	}
+	mx, my := stat.Mean(xs, nil), stat.Mean(ys, nil)
+	a := stat.Variance(xs, nil)
+	c := stat.Variance(ys, nil)
+	b := stat.Covariance(xs, ys, nil)
+
+	// собственные значения и поворот матрицы ковариации [[a b] [b c]]
+	d := math.Sqrt((a-c)*(a-c)/4 + b*b)
+	r1 := nstd * math.Sqrt(math.Max((a+c)/2+d, 0))
+	r2 := nstd * math.Sqrt(math.Max((a+c)/2-d, 0))
+	theta := math.Atan2(2*b, a-c) / 2
+	sin, cos := math.Sincos(theta)
+
+	ellipse := make(plotter.XYs, segments)
+	for i := range ellipse {
+		t := 2 * math.Pi * float64(i) / float64(segments)
+		st, ct := math.Sincos(t)
+		ellipse[i] = plotter.XY{
+			X: mx + r1*ct*cos - r2*st*sin,
+			Y: my + r1*ct*sin + r2*st*cos,
+		}
+	}
+	return ellipse
+}
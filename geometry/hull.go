@@ -0,0 +1,50 @@
+package geometry
+
+import (
+	"cmp"
+	"slices"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+// ConvexHull строит выпуклую оболочку точек алгоритмом монотонной цепочки
+// Эндрю. Вершины возвращаются против часовой стрелки, без повтора первой.
+// Для менее чем трех различных точек возвращаются сами точки.
+func ConvexHull(pts plotter.XYs) plotter.XYs {
+	sorted := slices.Clone(pts)
+	slices.SortFunc(sorted, func(a, b plotter.XY) int {
+		if a.X != b.X {
+			return cmp.Compare(a.X, b.X)
+		}
+		return cmp.Compare(a.Y, b.Y)
+	})
+	sorted = slices.Compact(sorted)
+	if len(sorted) < 3 {
+		return sorted
+	}
+
+	hull := make(plotter.XYs, 0, 2*len(sorted))
+	// нижняя цепочка
+	for _, p := range sorted {
+		for len(hull) >= 2 && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	// верхняя цепочка
+	lower := len(hull) + 1
+	for i := len(sorted) - 2; i >= 0; i-- {
+		p := sorted[i]
+		for len(hull) >= lower && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	return hull[:len(hull)-1]
+}
+
+// cross возвращает z-компоненту векторного произведения (a->b) x (a->c):
+// положительна, если поворот a-b-c против часовой стрелки.
+func cross(a, b, c plotter.XY) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
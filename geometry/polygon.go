@@ -0,0 +1,63 @@
+package geometry
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+// SignedArea возвращает площадь многоугольника по формуле шнурования:
+// положительную при обходе против часовой стрелки, отрицательную - по часовой.
+func SignedArea(poly plotter.XYs) float64 {
+	var sum float64
+	for i, p := range poly {
+		q := poly[(i+1)%len(poly)]
+		sum += p.X*q.Y - q.X*p.Y
+	}
+	return sum / 2
+}
+
+// Area возвращает площадь многоугольника.
+func Area(poly plotter.XYs) float64 {
+	return math.Abs(SignedArea(poly))
+}
+
+// Perimeter возвращает периметр замкнутого многоугольника.
+func Perimeter(poly plotter.XYs) float64 {
+	if len(poly) < 2 {
+		return 0
+	}
+	var sum float64
+	for i, p := range poly {
+		q := poly[(i+1)%len(poly)]
+		sum += math.Hypot(q.X-p.X, q.Y-p.Y)
+	}
+	return sum
+}
+
+// Shape - многоугольник с площадью и периметром для экспорта.
+type Shape struct {
+	Name      string      `json:"name,omitempty"`
+	Vertices  plotter.XYs `json:"vertices"`
+	Area      float64     `json:"area"`
+	Perimeter float64     `json:"perimeter"`
+}
+
+// NewShape считает площадь и периметр многоугольника.
+func NewShape(name string, poly plotter.XYs) Shape {
+	return Shape{
+		Name:      name,
+		Vertices:  poly,
+		Area:      Area(poly),
+		Perimeter: Perimeter(poly),
+	}
+}
+
+// WriteJSON записывает фигуры в w в формате JSON.
+func WriteJSON(w io.Writer, shapes []Shape) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(shapes)
+}
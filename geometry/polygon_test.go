@@ -0,0 +1,28 @@
+package geometry
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+func TestWriteJSON(t *testing.T) {
+	square := plotter.XYs{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 2}, {X: 0, Y: 2}}
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, []Shape{NewShape("square", square)}); err != nil {
+		t.Fatal(err)
+	}
+	var shapes []Shape
+	if err := json.Unmarshal(buf.Bytes(), &shapes); err != nil {
+		t.Fatal(err)
+	}
+	if len(shapes) != 1 {
+		t.Fatalf("got %d shapes, want 1", len(shapes))
+	}
+	s := shapes[0]
+	if s.Name != "square" || len(s.Vertices) != 4 || s.Area != 4 || s.Perimeter != 8 {
+		t.Errorf("shape = %+v, want the square with area 4 and perimeter 8", s)
+	}
+}
@@ -0,0 +1,77 @@
+package parallel
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Workers возвращает количество воркеров: при workers <= 0 берется
+// количество доступных ядер.
+func Workers(workers int) int {
+	if workers <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return workers
+}
+
+// For вызывает fn для каждого индекса из [0, n), распределяя индексы
+// между воркерами непрерывными блоками.
+func For(n, workers int, fn func(i int)) {
+	Range(n, workers, func(from, to int) {
+		for i := from; i < to; i++ {
+			fn(i)
+		}
+	})
+}
+
+// Range делит [0, n) на непрерывные блоки по числу воркеров и вызывает fn
+// для каждого блока в отдельной горутине.
+func Range(n, workers int, fn func(from, to int)) {
+	workers = Workers(workers)
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		if n > 0 {
+			fn(0, n)
+		}
+		return
+	}
+
+	var wg sync.WaitGroup
+	size := (n + workers - 1) / workers
+	for from := 0; from < n; from += size {
+		to := min(from+size, n)
+		wg.Add(1)
+		go func(from, to int) {
+			defer wg.Done()
+			fn(from, to)
+		}(from, to)
+	}
+	wg.Wait()
+}
+
+// Map вычисляет fn для каждого индекса из [0, n) параллельно и возвращает
+// результаты в порядке индексов.
+func Map[T any](n, workers int, fn func(i int) T) []T {
+	result := make([]T, n)
+	For(n, workers, func(i int) {
+		result[i] = fn(i)
+	})
+	return result
+}
+
+// Concat параллельно вычисляет срезы для каждого индекса из [0, n) и
+// склеивает их в порядке индексов, как это сделал бы последовательный цикл.
+func Concat[T any](n, workers int, fn func(i int) []T) []T {
+	parts := Map(n, workers, fn)
+	total := 0
+	for _, p := range parts {
+		total += len(p)
+	}
+	result := make([]T, 0, total)
+	for _, p := range parts {
+		result = append(result, p...)
+	}
+	return result
+}
@@ -0,0 +1,107 @@
+package parallel
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+var workerCounts = []int{-1, 0, 1, 2, 3, 7, 100}
+
+func TestForVisitsEachIndexOnce(t *testing.T) {
+	for _, n := range []int{0, 1, 10, 101} {
+		for _, w := range workerCounts {
+			visits := make([]int32, n)
+			For(n, w, func(i int) {
+				atomic.AddInt32(&visits[i], 1)
+			})
+			for i, v := range visits {
+				if v != 1 {
+					t.Errorf("n=%d workers=%d: index %d visited %d times", n, w, i, v)
+				}
+			}
+		}
+	}
+}
+
+func TestRangeCoversInterval(t *testing.T) {
+	for _, n := range []int{0, 1, 10, 101} {
+		for _, w := range workerCounts {
+			var covered, blocks int32
+			Range(n, w, func(from, to int) {
+				if from >= to {
+					t.Errorf("n=%d workers=%d: empty block [%d, %d)", n, w, from, to)
+				}
+				atomic.AddInt32(&covered, int32(to-from))
+				atomic.AddInt32(&blocks, 1)
+			})
+			if int(covered) != n {
+				t.Errorf("n=%d workers=%d: covered %d indices", n, w, covered)
+			}
+			if w > 0 && int(blocks) > w {
+				t.Errorf("n=%d workers=%d: %d blocks", n, w, blocks)
+			}
+		}
+	}
+}
+
+func TestMapMatchesSequential(t *testing.T) {
+	fn := func(i int) float64 { return math.Sqrt(float64(i)) }
+	want := make([]float64, 101)
+	for i := range want {
+		want[i] = fn(i)
+	}
+	for _, w := range workerCounts {
+		if got := Map(len(want), w, fn); !slices.Equal(want, got) {
+			t.Errorf("workers=%d: Map differs from the sequential loop", w)
+		}
+	}
+}
+
+func TestConcatMatchesSequential(t *testing.T) {
+	// срезы разной длины, включая пустые
+	fn := func(i int) []int {
+		part := make([]int, i%4)
+		for j := range part {
+			part[j] = i*10 + j
+		}
+		return part
+	}
+	var want []int
+	for i := 0; i < 101; i++ {
+		want = append(want, fn(i)...)
+	}
+	for _, w := range workerCounts {
+		if got := Concat(101, w, fn); !slices.Equal(want, got) {
+			t.Errorf("workers=%d: Concat differs from the sequential loop", w)
+		}
+	}
+}
+
+func BenchmarkMap(b *testing.B) {
+	const n = 1 << 16
+	work := func(i int) float64 {
+		s := 0.0
+		for j := 1; j < 64; j++ {
+			s += math.Sqrt(float64(i * j))
+		}
+		return s
+	}
+	b.Run("sequential", func(b *testing.B) {
+		result := make([]float64, n)
+		for k := 0; k < b.N; k++ {
+			for i := range result {
+				result[i] = work(i)
+			}
+		}
+	})
+	for _, w := range []int{1, 2, 4, 0} {
+		b.Run(fmt.Sprintf("workers=%d", w), func(b *testing.B) {
+			for k := 0; k < b.N; k++ {
+				Map(n, w, work)
+			}
+		})
+	}
+}
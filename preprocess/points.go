@@ -0,0 +1,114 @@
+package preprocess
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"algos/cluster"
+
+	"gonum.org/v1/gonum/stat"
+)
+
+// Rows переводит точки в строки признаков (x, y).
+func Rows(points []cluster.Point) [][]float64 {
+	rows := make([][]float64, len(points))
+	for i, p := range points {
+		rows[i] = []float64{p.X, p.Y}
+	}
+	return rows
+}
+
+// Points собирает точки из строк (x, y), веса берутся из like.
+// При like == nil веса не задаются.
+func Points(rows [][]float64, like []cluster.Point) []cluster.Point {
+	points := make([]cluster.Point, len(rows))
+	for i, row := range rows {
+		points[i] = cluster.Point{X: row[0], Y: row[1]}
+		if like != nil {
+			points[i].W = like[i].W
+		}
+	}
+	return points
+}
+
+// FitPoints обучает масштабирование на точках и возвращает их в новом масштабе.
+func FitPoints(s Scaler, points []cluster.Point) ([]cluster.Point, error) {
+	rows := Rows(points)
+	if err := s.Fit(rows); err != nil {
+		return nil, err
+	}
+	scaled, err := s.Transform(rows)
+	if err != nil {
+		return nil, err
+	}
+	return Points(scaled, points), nil
+}
+
+// UnscalePoints возвращает точки, например центроиды, в исходные единицы.
+func UnscalePoints(s Scaler, points []cluster.Point) ([]cluster.Point, error) {
+	rows, err := s.InverseTransform(Rows(points))
+	if err != nil {
+		return nil, err
+	}
+	return Points(rows, points), nil
+}
+
+// PointScaling переводит масштабирование, обученное на точках, в вид,
+// который сохраняется вместе с моделью кластеризации, см. cluster.ScaledModel.
+func PointScaling(s Scaler) (cluster.Scaling, error) {
+	var (
+		name string
+		a    *affine
+	)
+	switch s := s.(type) {
+	case *ZScore:
+		name, a = "zscore", &s.affine
+	case *MinMax:
+		name, a = "minmax", &s.affine
+	case *Robust:
+		name, a = "robust", &s.affine
+	case *UnitNorm:
+		return cluster.Scaling{Name: "unit", Scale: [2]float64{1, 1}, Unit: true}, nil
+	default:
+		return cluster.Scaling{}, fmt.Errorf("scaler %T can not be saved with a model", s)
+	}
+	if a.Scale == nil {
+		return cluster.Scaling{}, errors.New("scaler is not fitted")
+	}
+	if len(a.Scale) != 2 {
+		return cluster.Scaling{}, fmt.Errorf("scaler was fitted on %d features, points have 2", len(a.Scale))
+	}
+	return cluster.Scaling{
+		Name:  name,
+		Shift: [2]float64{a.Shift[0], a.Shift[1]},
+		Scale: [2]float64{a.Scale[0], a.Scale[1]},
+	}, nil
+}
+
+// FilterOutliers возвращает индексы точек, у которых обе координаты
+// отстоят от взвешенного среднего не больше чем на k взвешенных
+// стандартных отклонений. Меньше двух точек возвращаются все.
+func FilterOutliers(points []cluster.Point, k float64) []int {
+	if len(points) < 2 {
+		kept := make([]int, len(points))
+		for i := range kept {
+			kept[i] = i
+		}
+		return kept
+	}
+	xs, ys, ws := make([]float64, len(points)), make([]float64, len(points)), make([]float64, len(points))
+	for i, p := range points {
+		xs[i], ys[i], ws[i] = p.X, p.Y, p.Weight()
+	}
+	meanX, stdX := stat.MeanStdDev(xs, ws)
+	meanY, stdY := stat.MeanStdDev(ys, ws)
+
+	var kept []int
+	for i, p := range points {
+		if math.Abs(p.X-meanX) <= k*stdX && math.Abs(p.Y-meanY) <= k*stdY {
+			kept = append(kept, i)
+		}
+	}
+	return kept
+}
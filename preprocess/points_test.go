@@ -0,0 +1,33 @@
+package preprocess
+
+import (
+	"slices"
+	"testing"
+
+	"algos/cluster"
+)
+
+func TestFilterOutliers(t *testing.T) {
+	// v100: два выброса далеко от остальных точек
+	points := []cluster.Point{
+		{X: 350, Y: 2000}, {X: 2050, Y: 350},
+		{X: 300, Y: 300}, {X: 312, Y: 290}, {X: 302, Y: 315}, {X: 278, Y: 255},
+		{X: 700, Y: 700}, {X: 726, Y: 702}, {X: 666, Y: 653}, {X: 612, Y: 623},
+		{X: 400, Y: 500}, {X: 434, Y: 561}, {X: 322, Y: 433}, {X: 402, Y: 441},
+		{X: 355, Y: 412}, {X: 100, Y: 700}, {X: 32, Y: 615}, {X: 125, Y: 670},
+	}
+	kept := FilterOutliers(points, 2)
+	if want := []int{2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}; !slices.Equal(kept, want) {
+		t.Errorf("kept %v, want %v", kept, want)
+	}
+
+	// тяжелый выброс сдвигает среднее к себе и остается
+	points[0].W = 20
+	if kept := FilterOutliers(points, 2); kept[0] != 0 {
+		t.Errorf("kept %v, want the heavy point", kept)
+	}
+
+	if kept := FilterOutliers(points[:1], 2); !slices.Equal(kept, []int{0}) {
+		t.Errorf("single point: kept %v", kept)
+	}
+}
@@ -0,0 +1,182 @@
+package preprocess
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"gonum.org/v1/gonum/stat"
+)
+
+// Scaler приводит признаки к общему масштабу. Строки - наблюдения,
+// столбцы - признаки; Fit запоминает параметры по обучающим строкам,
+// Transform и InverseTransform применяют их к любым строкам той же ширины.
+type Scaler interface {
+	Fit(rows [][]float64) error
+	Transform(rows [][]float64) ([][]float64, error)
+	InverseTransform(rows [][]float64) ([][]float64, error)
+}
+
+// New возвращает масштабирование по названию: zscore, minmax, robust или unit.
+func New(name string) (Scaler, error) {
+	switch name {
+	case "zscore":
+		return &ZScore{}, nil
+	case "minmax":
+		return &MinMax{}, nil
+	case "robust":
+		return &Robust{}, nil
+	case "unit":
+		return &UnitNorm{}, nil
+	}
+	return nil, fmt.Errorf("unknown scaler %q, want zscore, minmax, robust or unit", name)
+}
+
+// affine - масштабирование вида (x - Shift) / Scale по каждому признаку.
+type affine struct {
+	Shift []float64 `json:"shift"`
+	Scale []float64 `json:"scale"`
+}
+
+func (a *affine) Transform(rows [][]float64) ([][]float64, error) {
+	return a.apply(rows, func(v float64, j int) float64 {
+		return (v - a.Shift[j]) / a.Scale[j]
+	})
+}
+
+func (a *affine) InverseTransform(rows [][]float64) ([][]float64, error) {
+	return a.apply(rows, func(v float64, j int) float64 {
+		return v*a.Scale[j] + a.Shift[j]
+	})
+}
+
+func (a *affine) apply(rows [][]float64, fn func(v float64, j int) float64) ([][]float64, error) {
+	if a.Scale == nil {
+		return nil, errors.New("scaler is not fitted")
+	}
+	result := make([][]float64, len(rows))
+	for i, row := range rows {
+		if len(row) != len(a.Scale) {
+			return nil, fmt.Errorf("row %d has %d features, the scaler was fitted on %d", i, len(row), len(a.Scale))
+		}
+		result[i] = make([]float64, len(row))
+		for j, v := range row {
+			result[i][j] = fn(v, j)
+		}
+	}
+	return result, nil
+}
+
+// fit считает сдвиг и масштаб каждого столбца. Нулевой масштаб
+// (постоянный признак) заменяется на 1, чтобы не делить на ноль.
+func (a *affine) fit(rows [][]float64, stats func(col []float64) (shift, scale float64)) error {
+	cols, err := columns(rows)
+	if err != nil {
+		return err
+	}
+	a.Shift = make([]float64, len(cols))
+	a.Scale = make([]float64, len(cols))
+	for j, col := range cols {
+		a.Shift[j], a.Scale[j] = stats(col)
+		if a.Scale[j] == 0 || math.IsNaN(a.Scale[j]) {
+			a.Scale[j] = 1
+		}
+	}
+	return nil
+}
+
+// ZScore вычитает среднее и делит на стандартное отклонение признака.
+type ZScore struct {
+	affine
+}
+
+// Fit реализует Scaler.
+func (s *ZScore) Fit(rows [][]float64) error {
+	return s.fit(rows, func(col []float64) (float64, float64) {
+		return stat.PopMeanStdDev(col, nil)
+	})
+}
+
+// MinMax переводит каждый признак в отрезок [0, 1].
+type MinMax struct {
+	affine
+}
+
+// Fit реализует Scaler.
+func (s *MinMax) Fit(rows [][]float64) error {
+	return s.fit(rows, func(col []float64) (float64, float64) {
+		lo, hi := slices.Min(col), slices.Max(col)
+		return lo, hi - lo
+	})
+}
+
+// Robust вычитает медиану и делит на межквартильный размах, поэтому
+// устойчив к выбросам.
+type Robust struct {
+	affine
+}
+
+// Fit реализует Scaler.
+func (s *Robust) Fit(rows [][]float64) error {
+	return s.fit(rows, func(col []float64) (float64, float64) {
+		sorted := slices.Clone(col)
+		slices.Sort(sorted)
+		q1 := stat.Quantile(0.25, stat.LinInterp, sorted, nil)
+		median := stat.Quantile(0.5, stat.LinInterp, sorted, nil)
+		q3 := stat.Quantile(0.75, stat.LinInterp, sorted, nil)
+		return median, q3 - q1
+	})
+}
+
+// UnitNorm делит каждую строку на ее евклидову длину. Длины не
+// запоминаются, поэтому обратное преобразование невозможно.
+type UnitNorm struct{}
+
+// Fit реализует Scaler, параметров у масштабирования нет.
+func (s *UnitNorm) Fit(rows [][]float64) error {
+	_, err := columns(rows)
+	return err
+}
+
+// Transform реализует Scaler. Нулевые строки остаются нулевыми.
+func (s *UnitNorm) Transform(rows [][]float64) ([][]float64, error) {
+	result := make([][]float64, len(rows))
+	for i, row := range rows {
+		var norm float64
+		for _, v := range row {
+			norm += v * v
+		}
+		norm = math.Sqrt(norm)
+		result[i] = slices.Clone(row)
+		if norm == 0 {
+			continue
+		}
+		for j := range result[i] {
+			result[i][j] /= norm
+		}
+	}
+	return result, nil
+}
+
+// InverseTransform реализует Scaler и всегда возвращает ошибку.
+func (s *UnitNorm) InverseTransform(rows [][]float64) ([][]float64, error) {
+	return nil, errors.New("unit norm scaling can not be inverted")
+}
+
+// columns раскладывает строки по столбцам, проверяя ширину строк.
+func columns(rows [][]float64) ([][]float64, error) {
+	if len(rows) == 0 {
+		return nil, errors.New("no rows to fit")
+	}
+	cols := make([][]float64, len(rows[0]))
+	for i, row := range rows {
+		if len(row) != len(cols) {
+			return nil, fmt.Errorf("row %d has %d features, want %d", i, len(row), len(cols))
+		}
+		for j, v := range row {
+			cols[j] = append(cols[j], v)
+		}
+	}
+	return cols, nil
+}
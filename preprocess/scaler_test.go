@@ -0,0 +1,152 @@
+package preprocess
+
+import (
+	"math"
+	"testing"
+
+	"algos/cluster"
+)
+
+var testRows = [][]float64{
+	{1, 10, 5},
+	{2, 20, 5},
+	{4, 30, 5},
+	{8, 1000, 5},
+}
+
+func near(a, b [][]float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return false
+		}
+		for j := range a[i] {
+			if math.Abs(a[i][j]-b[i][j]) > 1e-9 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestScalerRoundTrip(t *testing.T) {
+	for _, name := range []string{"zscore", "minmax", "robust"} {
+		s, err := New(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Fit(testRows); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		scaled, err := s.Transform(testRows)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		// постоянный признак не делится на ноль и становится нулем
+		for i, row := range scaled {
+			if row[2] != 0 {
+				t.Errorf("%s: row %d: constant feature scaled to %g, want 0", name, i, row[2])
+			}
+		}
+		back, err := s.InverseTransform(scaled)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !near(back, testRows) {
+			t.Errorf("%s: inverse transform gives %v, want %v", name, back, testRows)
+		}
+	}
+}
+
+func TestScalerValues(t *testing.T) {
+	for name, tc := range map[string]struct {
+		s    Scaler
+		want []float64
+	}{
+		// первый признак: среднее 3.75, стандартное отклонение sqrt(7.1875)
+		"zscore": {&ZScore{}, []float64{(1 - 3.75) / math.Sqrt(7.1875), (8 - 3.75) / math.Sqrt(7.1875)}},
+		"minmax": {&MinMax{}, []float64{0, 1}},
+		// квантили stat.LinInterp по 1, 2, 4, 8: медиана 2, квартили 1 и 4
+		"robust": {&Robust{}, []float64{(1 - 2) / 3.0, (8 - 2) / 3.0}},
+	} {
+		if err := tc.s.Fit(testRows); err != nil {
+			t.Fatal(err)
+		}
+		scaled, err := tc.s.Transform(testRows)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := []float64{scaled[0][0], scaled[3][0]}
+		if math.Abs(got[0]-tc.want[0]) > 1e-9 || math.Abs(got[1]-tc.want[1]) > 1e-9 {
+			t.Errorf("%s: first feature of the first and last rows = %v, want %v", name, got, tc.want)
+		}
+	}
+}
+
+func TestUnitNorm(t *testing.T) {
+	s := &UnitNorm{}
+	if err := s.Fit(testRows); err != nil {
+		t.Fatal(err)
+	}
+	scaled, err := s.Transform([][]float64{{3, 4}, {0, 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !near(scaled, [][]float64{{0.6, 0.8}, {0, 0}}) {
+		t.Errorf("scaled = %v, want [[0.6 0.8] [0 0]]", scaled)
+	}
+	if _, err := s.InverseTransform(scaled); err == nil {
+		t.Error("inverse transform: want error")
+	}
+}
+
+func TestScalerErrors(t *testing.T) {
+	s := &ZScore{}
+	if _, err := s.Transform(testRows); err == nil {
+		t.Error("transform before fit: want error")
+	}
+	if err := s.Fit(nil); err == nil {
+		t.Error("fit without rows: want error")
+	}
+	if err := s.Fit([][]float64{{1, 2}, {3}}); err == nil {
+		t.Error("fit on ragged rows: want error")
+	}
+	if err := s.Fit(testRows); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Transform([][]float64{{1, 2}}); err == nil {
+		t.Error("transform of a narrower row: want error")
+	}
+	if _, err := New("log"); err == nil {
+		t.Error("unknown scaler: want error")
+	}
+}
+
+func TestPointScaling(t *testing.T) {
+	points := []cluster.Point{{X: 0, Y: 10}, {X: 4, Y: 10, W: 2}, {X: 2, Y: 30}}
+	for _, name := range []string{"zscore", "minmax", "robust", "unit"} {
+		s, err := New(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scaled, err := FitPoints(s, points)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scaling, err := PointScaling(s)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for i, p := range points {
+			got := scaling.Apply(p)
+			if math.Abs(got.X-scaled[i].X) > 1e-9 || math.Abs(got.Y-scaled[i].Y) > 1e-9 || got.W != p.W {
+				t.Errorf("%s: point %d scales to %v, want %v", name, i, got, scaled[i])
+			}
+		}
+	}
+	if _, err := PointScaling(&MinMax{}); err == nil {
+		t.Error("unfitted scaler: want error")
+	}
+}
@@ -0,0 +1,81 @@
+package reduce
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"algos/distmat"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// MDS - классическое многомерное шкалирование: восстанавливает координаты
+// точек в k измерениях по матрице попарных расстояний. Возвращает
+// координаты и k наибольших собственных значений матрицы Грама.
+// Отрицательные собственные значения (расстояния не евклидовы) дают
+// нулевые координаты по своей оси.
+func MDS[T distmat.Float](dm *distmat.Matrix[T], k int) ([][]float64, []float64, error) {
+	n := dm.Len()
+	if n == 0 {
+		return nil, nil, errors.New("no data")
+	}
+	if k <= 0 || k > n {
+		return nil, nil, fmt.Errorf("k must be in [1, %d], got %d", n, k)
+	}
+
+	// двойное центрирование квадратов расстояний: B = -1/2 J D² J
+	sq := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			d := float64(dm.At(i, j))
+			sq[i*n+j], sq[j*n+i] = d*d, d*d
+		}
+	}
+	rowMean := make([]float64, n)
+	var mean float64
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			rowMean[i] += sq[i*n+j]
+		}
+		mean += rowMean[i]
+		rowMean[i] /= float64(n)
+	}
+	mean /= float64(n * n)
+
+	b := mat.NewSymDense(n, nil)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			b.SetSym(i, j, -0.5*(sq[i*n+j]-rowMean[i]-rowMean[j]+mean))
+		}
+	}
+
+	var eig mat.EigenSym
+	if !eig.Factorize(b, true) {
+		return nil, nil, errors.New("eigendecomposition did not converge")
+	}
+	// собственные значения идут по возрастанию
+	values := eig.Values(nil)
+	var vectors mat.Dense
+	eig.VectorsTo(&vectors)
+
+	coords := make([][]float64, n)
+	for i := range coords {
+		coords[i] = make([]float64, k)
+	}
+	top := make([]float64, k)
+	for c := 0; c < k; c++ {
+		col := n - 1 - c
+		top[c] = values[col]
+		if top[c] <= 0 {
+			continue
+		}
+		vec := mat.Col(nil, col, &vectors)
+		flipSign(vec)
+		scale := math.Sqrt(top[c])
+		for i := range coords {
+			coords[i][c] = vec[i] * scale
+		}
+	}
+	return coords, top, nil
+}
@@ -0,0 +1,46 @@
+package reduce
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"algos/distmat"
+)
+
+func TestMDSPreservesDistances(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	points := make([][2]float64, 25)
+	for i := range points {
+		points[i] = [2]float64{rng.Float64() * 10, rng.Float64() * 4}
+	}
+	dm := distmat.New[float64](len(points), func(i, j int) float64 {
+		return math.Hypot(points[i][0]-points[j][0], points[i][1]-points[j][1])
+	}, 0)
+
+	coords, values, err := MDS(dm, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values[0] < values[1] || values[1] <= 0 {
+		t.Errorf("eigenvalues = %v, want two positive in decreasing order", values)
+	}
+	for i := range coords {
+		for j := i + 1; j < len(coords); j++ {
+			got := math.Hypot(coords[i][0]-coords[j][0], coords[i][1]-coords[j][1])
+			if want := dm.At(i, j); math.Abs(got-want) > 1e-9 {
+				t.Fatalf("distance %d-%d is %g, want %g", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestMDSErrors(t *testing.T) {
+	if _, _, err := MDS(distmat.New[float64](0, nil, 0), 1); err == nil {
+		t.Error("no points: want error")
+	}
+	dm := distmat.New[float64](3, func(i, j int) float64 { return 1 }, 0)
+	if _, _, err := MDS(dm, 4); err == nil {
+		t.Error("k above the point count: want error")
+	}
+}
@@ -0,0 +1,160 @@
+package reduce
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// PCA - метод главных компонент: проекция строк на k направлений
+// наибольшей дисперсии.
+type PCA struct {
+	// Mean - среднее каждого признака обучающих строк.
+	Mean []float64 `json:"mean"`
+	// Components - главные направления единичной длины, по одному в строке,
+	// по убыванию дисперсии.
+	Components [][]float64 `json:"components"`
+	// Variance - дисперсия данных вдоль каждой компоненты.
+	Variance []float64 `json:"variance"`
+	// Ratio - доля общей дисперсии, объясненная каждой компонентой.
+	Ratio []float64 `json:"ratio"`
+}
+
+// FitPCA находит k главных компонент строк через SVD центрированной матрицы.
+// Знак каждой компоненты выбирается так, чтобы наибольшая по модулю
+// координата была положительной, поэтому результат воспроизводим.
+func FitPCA(rows [][]float64, k int) (*PCA, error) {
+	x, err := dense(rows)
+	if err != nil {
+		return nil, err
+	}
+	n, d := x.Dims()
+	if k <= 0 || k > min(n, d) {
+		return nil, fmt.Errorf("k must be in [1, %d], got %d", min(n, d), k)
+	}
+
+	mean := make([]float64, d)
+	for j := range mean {
+		mean[j] = mat.Sum(x.ColView(j)) / float64(n)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < d; j++ {
+			x.Set(i, j, x.At(i, j)-mean[j])
+		}
+	}
+
+	var svd mat.SVD
+	if !svd.Factorize(x, mat.SVDThinV) {
+		return nil, errors.New("svd did not converge")
+	}
+	values := svd.Values(nil)
+	var v mat.Dense
+	svd.VTo(&v)
+
+	// несмещенная оценка дисперсии, для одной строки она нулевая
+	denom := float64(max(n-1, 1))
+	var total float64
+	for _, s := range values {
+		total += s * s / denom
+	}
+
+	p := &PCA{
+		Mean:       mean,
+		Components: make([][]float64, k),
+		Variance:   make([]float64, k),
+		Ratio:      make([]float64, k),
+	}
+	for c := 0; c < k; c++ {
+		comp := mat.Col(nil, c, &v)
+		flipSign(comp)
+		p.Components[c] = comp
+		p.Variance[c] = values[c] * values[c] / denom
+		if total > 0 {
+			p.Ratio[c] = p.Variance[c] / total
+		}
+	}
+	return p, nil
+}
+
+// Explained возвращает долю общей дисперсии, объясненную всеми компонентами.
+func (p *PCA) Explained() float64 {
+	var sum float64
+	for _, r := range p.Ratio {
+		sum += r
+	}
+	return sum
+}
+
+// Transform проецирует строки на главные компоненты.
+func (p *PCA) Transform(rows [][]float64) ([][]float64, error) {
+	result := make([][]float64, len(rows))
+	for i, row := range rows {
+		if len(row) != len(p.Mean) {
+			return nil, fmt.Errorf("row %d has %d features, the pca was fitted on %d", i, len(row), len(p.Mean))
+		}
+		result[i] = make([]float64, len(p.Components))
+		for c, comp := range p.Components {
+			for j, v := range row {
+				result[i][c] += (v - p.Mean[j]) * comp[j]
+			}
+		}
+	}
+	return result, nil
+}
+
+// InverseTransform возвращает проекции в исходное пространство. Без всех
+// компонент восстановление приближенное: теряется отброшенная дисперсия.
+func (p *PCA) InverseTransform(rows [][]float64) ([][]float64, error) {
+	result := make([][]float64, len(rows))
+	for i, row := range rows {
+		if len(row) != len(p.Components) {
+			return nil, fmt.Errorf("row %d has %d components, want %d", i, len(row), len(p.Components))
+		}
+		result[i] = append([]float64(nil), p.Mean...)
+		for c, v := range row {
+			for j, w := range p.Components[c] {
+				result[i][j] += v * w
+			}
+		}
+	}
+	return result, nil
+}
+
+// flipSign меняет знак вектора, если его наибольшая по модулю координата
+// отрицательна.
+func flipSign(v []float64) {
+	var big float64
+	for _, x := range v {
+		if math.Abs(x) > math.Abs(big) {
+			big = x
+		}
+	}
+	if big < 0 {
+		for i := range v {
+			v[i] = -v[i]
+		}
+	}
+}
+
+// dense собирает матрицу из строк одинаковой ширины.
+func dense(rows [][]float64) (*mat.Dense, error) {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return nil, errors.New("no data")
+	}
+	d := len(rows[0])
+	data := make([]float64, 0, len(rows)*d)
+	for i, row := range rows {
+		if len(row) != d {
+			return nil, fmt.Errorf("row %d has %d features, want %d", i, len(row), d)
+		}
+		for _, v := range row {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return nil, fmt.Errorf("row %d has a non-finite value", i)
+			}
+		}
+		data = append(data, row...)
+	}
+	return mat.NewDense(len(rows), d, data), nil
+}
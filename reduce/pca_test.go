@@ -0,0 +1,90 @@
+package reduce
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func randomRows(n, d int, rng *rand.Rand) [][]float64 {
+	rows := make([][]float64, n)
+	for i := range rows {
+		rows[i] = make([]float64, d)
+		for j := range rows[i] {
+			// признаки с разным разбросом, чтобы компоненты различались
+			rows[i][j] = rng.NormFloat64() * float64(j+1)
+		}
+	}
+	return rows
+}
+
+func TestPCAExplained(t *testing.T) {
+	rows := randomRows(50, 4, rand.New(rand.NewSource(1)))
+	p, err := FitPCA(rows, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.Explained(); math.Abs(got-1) > 1e-9 {
+		t.Errorf("all components explain %g of the variance, want 1", got)
+	}
+	for c := 1; c < len(p.Ratio); c++ {
+		if p.Ratio[c] > p.Ratio[c-1] {
+			t.Errorf("ratios %v are not decreasing", p.Ratio)
+		}
+	}
+
+	p, err = FitPCA(rows, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.Explained(); got >= 1 || got <= 0 {
+		t.Errorf("two components explain %g of the variance, want a part of it", got)
+	}
+}
+
+func TestPCAInverseRankDeficient(t *testing.T) {
+	// точки лежат в плоскости z = x + 2y, двух компонент достаточно
+	rng := rand.New(rand.NewSource(2))
+	rows := make([][]float64, 30)
+	for i := range rows {
+		x, y := rng.NormFloat64(), rng.NormFloat64()*3
+		rows[i] = []float64{x, y, x + 2*y}
+	}
+	p, err := FitPCA(rows, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.Explained(); math.Abs(got-1) > 1e-9 {
+		t.Errorf("two components explain %g of the variance, want 1", got)
+	}
+	projected, err := p.Transform(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	back, err := p.InverseTransform(projected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range rows {
+		for j := range rows[i] {
+			if math.Abs(back[i][j]-rows[i][j]) > 1e-9 {
+				t.Fatalf("row %d is restored as %v, want %v", i, back[i], rows[i])
+			}
+		}
+	}
+}
+
+func TestPCAErrors(t *testing.T) {
+	if _, err := FitPCA(nil, 1); err == nil {
+		t.Error("no rows: want error")
+	}
+	if _, err := FitPCA([][]float64{{1, 2}, {3}}, 1); err == nil {
+		t.Error("ragged rows: want error")
+	}
+	if _, err := FitPCA([][]float64{{1, 2}, {3, 4}}, 3); err == nil {
+		t.Error("k above the rank bound: want error")
+	}
+	if _, err := FitPCA([][]float64{{1, math.NaN()}}, 1); err == nil {
+		t.Error("NaN: want error")
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"algos/cluster"
+	"algos/drawer"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"time"
+)
+
+// request is the body of POST /cluster.
+type request struct {
+	// Algorithm is one of dbscan, kmeans, muesli.
+	Algorithm string          `json:"algorithm"`
+	Points    []cluster.Point `json:"points"`
+	Eps       float64         `json:"eps"`
+	MinPts    int             `json:"minPts"`
+	K         int             `json:"k"`
+	// Predict holds new points to assign to the found clusters.
+	Predict []cluster.Point `json:"predict"`
+	// PNG asks for a scatter plot of the result.
+	PNG bool `json:"png"`
+}
+
+// response is the result of a clustering. Labels start at 1, 0 is noise.
+type response struct {
+	Labels    []int           `json:"labels"`
+	Clusters  int             `json:"clusters"`
+	Noise     int             `json:"noise"`
+	Centroids []cluster.Point `json:"centroids"`
+	Metrics   cluster.Metrics `json:"metrics"`
+	// Predictions are the labels of the request Predict points.
+	Predictions []int `json:"predictions,omitempty"`
+	// PNG is base64 encoded in JSON.
+	PNG []byte `json:"png,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// server holds the request limits. The algorithms cannot be interrupted
+// once started, so maxPoints and maxK bound the work of a single request.
+type server struct {
+	maxBody   int64
+	maxPoints int
+	maxK      int
+}
+
+// withTimeout is http.TimeoutHandler with a JSON error body: the handler
+// sets its own headers, so the Content-Type has to be set beforehand for the
+// timeout response.
+func withTimeout(h http.Handler, timeout time.Duration) http.Handler {
+	th := http.TimeoutHandler(h, timeout, `{"error":"request timed out"}`+"\n")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		th.ServeHTTP(w, r)
+	})
+}
+
+func (s *server) handleCluster(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", r.Method))
+		return
+	}
+
+	var req request
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, s.maxBody))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("body is larger than %d bytes", s.maxBody))
+			return
+		}
+		writeError(w, http.StatusBadRequest, fmt.Errorf("could not decode request: %v", err))
+		return
+	}
+	if len(req.Points) == 0 {
+		writeError(w, http.StatusBadRequest, errors.New("no points"))
+		return
+	}
+	if n := len(req.Points) + len(req.Predict); n > s.maxPoints {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("got %d points, the limit is %d", n, s.maxPoints))
+		return
+	}
+	if req.K > s.maxK {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Errorf("k is %d, the limit is %d", req.K, s.maxK))
+		return
+	}
+	if err := validatePoints(req.Points); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := validatePoints(req.Predict); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("predict: %v", err))
+		return
+	}
+
+	resp, err := run(r.Context(), req)
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		// the client is gone or TimeoutHandler has already answered
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// validatePoints rejects coordinates and weights the algorithms cannot use.
+func validatePoints(points []cluster.Point) error {
+	for i, p := range points {
+		for _, v := range []float64{p.X, p.Y, p.W} {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return fmt.Errorf("point %d is not finite", i)
+			}
+		}
+		if p.W < 0 {
+			return fmt.Errorf("point %d has negative weight %g", i, p.W)
+		}
+	}
+	return nil
+}
+
+// run clusters the points and renders the optional plot. The context is
+// checked between the steps, so a timed out request stops at the next one.
+func run(ctx context.Context, req request) (*response, error) {
+	var c cluster.Clusterer
+	switch req.Algorithm {
+	case "dbscan":
+		c = cluster.DBSCANClusterer{Eps: req.Eps, MinPts: req.MinPts}
+	case "kmeans":
+		c = cluster.KMeansClusterer{K: req.K}
+	case "muesli":
+		c = cluster.MuesliClusterer{K: req.K}
+	default:
+		return nil, fmt.Errorf("unknown algorithm %q, want dbscan, kmeans or muesli", req.Algorithm)
+	}
+	res, err := c.Fit(req.Points)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	resp := &response{
+		Labels:    res.Labels,
+		Centroids: res.Centers(req.Points),
+		Metrics:   res.Evaluate(req.Points),
+	}
+	resp.Clusters, resp.Noise = res.Count()
+	if len(req.Predict) > 0 {
+		resp.Predictions = cluster.PredictBatch(res, req.Predict, 0)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if req.PNG {
+		clstrs, noise := res.Split(req.Points)
+		ch, err := drawer.ClastersChart(clstrs,
+			drawer.WithTitle(req.Algorithm),
+			drawer.WithGrid(),
+			drawer.WithLegend(),
+			drawer.WithNoise(noise),
+			drawer.WithCentroids(cluster.XYs(resp.Centroids)),
+			drawer.WithFormat("png"),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("could not plot: %v", err)
+		}
+		var buf bytes.Buffer
+		if _, err := ch.WriteTo(&buf); err != nil {
+			return nil, fmt.Errorf("could not render plot: %v", err)
+		}
+		resp.PNG = buf.Bytes()
+	}
+	return resp, nil
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("could not write response: %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"algos/cluster"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestServer() *server {
+	return &server{maxBody: 1 << 16, maxPoints: 10, maxK: 3}
+}
+
+func post(h http.Handler, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/cluster", strings.NewReader(body)))
+	return rec
+}
+
+func TestHandleCluster(t *testing.T) {
+	rec := post(http.HandlerFunc(newTestServer().handleCluster), `{
+		"algorithm": "dbscan", "eps": 2, "minPts": 2,
+		"points": [{"x": 0, "y": 0}, {"x": 1, "y": 0}, {"x": 10, "y": 10}, {"x": 11, "y": 10}, {"x": 50, "y": 50}],
+		"predict": [{"x": 0.5, "y": 0}]
+	}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	var resp response
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Clusters != 2 || resp.Noise != 1 {
+		t.Errorf("got %d clusters and %d noise points, want 2 and 1", resp.Clusters, resp.Noise)
+	}
+	if len(resp.Predictions) != 1 || resp.Predictions[0] != resp.Labels[0] {
+		t.Errorf("predictions = %v, want the label of the first point %d", resp.Predictions, resp.Labels[0])
+	}
+}
+
+func TestHandleClusterRejects(t *testing.T) {
+	h := http.HandlerFunc(newTestServer().handleCluster)
+	for _, tt := range []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"bad json", `{"algorithm":`, http.StatusBadRequest},
+		{"unknown field", `{"algorithm": "kmeans", "k": 1, "points": [{"x": 1, "y": 1}], "colour": 1}`, http.StatusBadRequest},
+		{"no points", `{"algorithm": "kmeans", "k": 1}`, http.StatusBadRequest},
+		{"negative weight", `{"algorithm": "kmeans", "k": 1, "points": [{"x": 1, "y": 1, "w": -1}, {"x": 3, "y": 3, "w": 1}]}`, http.StatusBadRequest},
+		{"negative predict weight", `{"algorithm": "kmeans", "k": 1, "points": [{"x": 1, "y": 1}], "predict": [{"x": 1, "y": 1, "w": -2}]}`, http.StatusBadRequest},
+		{"too many points", `{"algorithm": "kmeans", "k": 1, "points": [` + strings.Repeat(`{"x": 1, "y": 1},`, 10) + `{"x": 1, "y": 1}]}`, http.StatusRequestEntityTooLarge},
+		{"k over the limit", `{"algorithm": "kmeans", "k": 4, "points": [{"x": 1, "y": 1}]}`, http.StatusUnprocessableEntity},
+		{"unknown algorithm", `{"algorithm": "magic", "points": [{"x": 1, "y": 1}]}`, http.StatusUnprocessableEntity},
+		{"invalid parameters", `{"algorithm": "dbscan", "eps": -1, "minPts": 2, "points": [{"x": 1, "y": 1}]}`, http.StatusUnprocessableEntity},
+	} {
+		rec := post(h, tt.body)
+		if rec.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d, body %s", tt.name, rec.Code, tt.status, rec.Body)
+			continue
+		}
+		var e errorResponse
+		if err := json.NewDecoder(rec.Body).Decode(&e); err != nil || e.Error == "" {
+			t.Errorf("%s: body is not an error response: %v", tt.name, err)
+		}
+	}
+}
+
+func TestHandleClusterMethod(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTestServer().handleCluster(rec, httptest.NewRequest(http.MethodGet, "/cluster", nil))
+	if rec.Code != http.StatusMethodNotAllowed || rec.Header().Get("Allow") != http.MethodPost {
+		t.Errorf("status = %d, Allow = %q", rec.Code, rec.Header().Get("Allow"))
+	}
+}
+
+func TestHandleClusterBodyLimit(t *testing.T) {
+	s := newTestServer()
+	s.maxBody = 32
+	rec := post(http.HandlerFunc(s.handleCluster), `{"algorithm": "kmeans", "k": 1, "points": [{"x": 1, "y": 1}]}`)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusRequestEntityTooLarge)
+	}
+}
+
+func TestTimeoutIsJSON(t *testing.T) {
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	rec := post(withTimeout(slow, time.Millisecond), `{}`)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+	var e errorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&e); err != nil || e.Error == "" {
+		t.Errorf("body is not an error response: %v", err)
+	}
+}
+
+func TestWithTimeoutPassesResponse(t *testing.T) {
+	rec := post(withTimeout(http.HandlerFunc(newTestServer().handleCluster), time.Second), `{
+		"algorithm": "kmeans", "k": 2, "png": true,
+		"points": [{"x": 0, "y": 0}, {"x": 1, "y": 0}, {"x": 10, "y": 10}]
+	}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	var resp response
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil || len(resp.PNG) == 0 {
+		t.Errorf("want a response with a plot, got error %v", err)
+	}
+}
+
+func TestRunCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := request{Algorithm: "kmeans", K: 1, Points: []cluster.Point{{X: 1, Y: 1}}}
+	if _, err := run(ctx, req); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Clustering over HTTP:
+//
+//	POST /cluster {"algorithm": "dbscan", "eps": 150, "minPts": 3, "points": [{"x": 1, "y": 2}, ...]}
+//
+// answers with labels, centroids, quality metrics and, when "png" is set,
+// a base64 encoded scatter plot.
+func main() {
+	addr := flag.String("addr", ":8080", "listen address")
+	maxBody := flag.Int64("max-body", 1<<20, "maximum request body size in bytes")
+	maxPoints := flag.Int("max-points", 2000, "maximum number of points in a request")
+	maxK := flag.Int("max-k", 100, "maximum number of clusters in a request")
+	timeout := flag.Duration("timeout", 10*time.Second, "maximum time to handle a request")
+	flag.Parse()
+
+	s := &server{maxBody: *maxBody, maxPoints: *maxPoints, maxK: *maxK}
+	mux := http.NewServeMux()
+	mux.Handle("/cluster", withTimeout(http.HandlerFunc(s.handleCluster), *timeout))
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       *timeout,
+		WriteTimeout:      *timeout + 5*time.Second,
+	}
+	log.Printf("listening on %s", *addr)
+	log.Fatal(srv.ListenAndServe())
+}
@@ -0,0 +1,224 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ConstraintKind - тип ограничения между двумя точками.
+type ConstraintKind int
+
+const (
+	MustLink ConstraintKind = iota + 1
+	CannotLink
+)
+
+func (k ConstraintKind) String() string {
+	switch k {
+	case MustLink:
+		return "must-link"
+	case CannotLink:
+		return "cannot-link"
+	}
+	return fmt.Sprintf("ConstraintKind(%d)", int(k))
+}
+
+// Violation описывает нарушенное ограничение между точками A и B.
+type Violation struct {
+	Kind ConstraintKind
+	A, B int
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s %d-%d", v.Kind, v.A, v.B)
+}
+
+// Constraints хранит ограничения must-link и cannot-link между точками по их номерам.
+type Constraints struct {
+	mustLink   map[int]map[int]struct{}
+	cannotLink map[int]map[int]struct{}
+}
+
+// NewConstraints создает пустой набор ограничений.
+func NewConstraints() *Constraints {
+	return &Constraints{
+		mustLink:   make(map[int]map[int]struct{}),
+		cannotLink: make(map[int]map[int]struct{}),
+	}
+}
+
+// AddMustLink требует, чтобы точки a и b были в одном кластере.
+func (c *Constraints) AddMustLink(a, b int) {
+	link(c.mustLink, a, b)
+}
+
+// AddCannotLink требует, чтобы точки a и b были в разных кластерах.
+func (c *Constraints) AddCannotLink(a, b int) {
+	link(c.cannotLink, a, b)
+}
+
+// CannotLinks возвращает отсортированные номера точек, с которыми a не может быть в одном кластере.
+func (c *Constraints) CannotLinks(a int) []int {
+	return keys(c.cannotLink[a])
+}
+
+// MustLinks возвращает отсортированные номера точек, с которыми a должна быть в одном кластере.
+func (c *Constraints) MustLinks(a int) []int {
+	return keys(c.mustLink[a])
+}
+
+// CanAdd проверяет, можно ли добавить точку в кластер, не нарушив cannot-link.
+func (c *Constraints) CanAdd(cluster *Cluster, point int) bool {
+	for other := range c.cannotLink[point] {
+		if _, found := cluster.Points[other]; found {
+			return false
+		}
+	}
+	return true
+}
+
+// CanMerge проверяет, можно ли объединить кластеры, не нарушив cannot-link.
+func (c *Constraints) CanMerge(a, b *Cluster) bool {
+	for point := range a.Points {
+		if !c.CanAdd(b, point) {
+			return false
+		}
+	}
+	return true
+}
+
+// MustLinkGroups возвращает группы точек, связанных must-link транзитивно.
+// Точки без must-link ограничений в группы не входят.
+func (c *Constraints) MustLinkGroups() [][]int {
+	var groups [][]int
+	visited := make(map[int]bool)
+	for _, start := range keys(c.mustLink) {
+		if visited[start] {
+			continue
+		}
+		visited[start] = true
+		group := []int{start}
+		for i := 0; i < len(group); i++ {
+			for _, next := range keys(c.mustLink[group[i]]) {
+				if !visited[next] {
+					visited[next] = true
+					group = append(group, next)
+				}
+			}
+		}
+		sort.Ints(group)
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// Conflicts возвращает cannot-link ограничения внутри групп must-link,
+// которые невозможно выполнить ни при каком разбиении.
+func (c *Constraints) Conflicts() []Violation {
+	var result []Violation
+	for _, group := range c.MustLinkGroups() {
+		cluster := NewCluster(group)
+		for _, a := range group {
+			for _, b := range c.CannotLinks(a) {
+				if _, found := cluster.Points[b]; found && a < b {
+					result = append(result, Violation{Kind: CannotLink, A: a, B: b})
+				}
+			}
+		}
+	}
+	return result
+}
+
+// Check проверяет разбиение: labels сопоставляет номеру точки номер кластера,
+// 0 или отсутствие в labels означает шум. Must-link выполнено, только если
+// обе точки в одном кластере.
+func (c *Constraints) Check(labels map[int]int) []Violation {
+	var result []Violation
+	for _, a := range keys(c.mustLink) {
+		for _, b := range keys(c.mustLink[a]) {
+			if a < b && (labels[a] == 0 || labels[a] != labels[b]) {
+				result = append(result, Violation{Kind: MustLink, A: a, B: b})
+			}
+		}
+	}
+	for _, a := range keys(c.cannotLink) {
+		for _, b := range keys(c.cannotLink[a]) {
+			if a < b && labels[a] != 0 && labels[a] == labels[b] {
+				result = append(result, Violation{Kind: CannotLink, A: a, B: b})
+			}
+		}
+	}
+	return result
+}
+
+// MergeClustersConstrained объединяет пересекающиеся кластеры, как MergeClusters,
+// но никогда не объединяет кластеры, между точками которых есть cannot-link.
+// Общие точки таких кластеров остаются только в том, что идет раньше, так что
+// результат не пересекается; кластер, у которого не осталось точек, удаляется.
+// Объединение повторяется, пока кластеры меняются.
+func MergeClustersConstrained(clusters []*Cluster, c *Constraints) []*Cluster {
+	for {
+		changed := false
+		mergedClusters := make([]*Cluster, 0, len(clusters))
+		for _, cluster := range clusters {
+			merged := false
+			for _, mCluster := range mergedClusters {
+				if !cluster.HasIntersection(mCluster) {
+					continue
+				}
+				if c.CanMerge(cluster, mCluster) {
+					mCluster.Merge(cluster)
+					merged = true
+					break
+				}
+				for point := range mCluster.Points {
+					delete(cluster.Points, point)
+				}
+				changed = true
+			}
+			if merged {
+				changed = true
+			} else if len(cluster.Points) > 0 {
+				mergedClusters = append(mergedClusters, cluster)
+			}
+		}
+		if !changed {
+			return mergedClusters
+		}
+		clusters = mergedClusters
+	}
+}
+
+// Labels сопоставляет каждой точке номер ее кластера, начиная с 1.
+// Если точка входит в несколько кластеров, берется первый.
+func Labels(clusters []*Cluster) map[int]int {
+	labels := make(map[int]int)
+	for i, cluster := range clusters {
+		for point := range cluster.Points {
+			if _, found := labels[point]; !found {
+				labels[point] = i + 1
+			}
+		}
+	}
+	return labels
+}
+
+func link(links map[int]map[int]struct{}, a, b int) {
+	if links[a] == nil {
+		links[a] = make(map[int]struct{})
+	}
+	if links[b] == nil {
+		links[b] = make(map[int]struct{})
+	}
+	links[a][b] = struct{}{}
+	links[b][a] = struct{}{}
+}
+
+func keys[V any](m map[int]V) []int {
+	result := make([]int, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	sort.Ints(result)
+	return result
+}
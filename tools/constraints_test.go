@@ -0,0 +1,26 @@
+package tools
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMergeClustersConstrainedDisjoint(t *testing.T) {
+	cons := NewConstraints()
+	cons.AddCannotLink(1, 5)
+	merged := MergeClustersConstrained([]*Cluster{
+		NewCluster([]int{1, 2, 3}),
+		NewCluster([]int{3, 4, 5}),
+		NewCluster([]int{3}),
+		NewCluster([]int{5, 6}),
+	}, cons)
+
+	var got [][]int
+	for _, c := range merged {
+		got = append(got, c.GetPoints())
+	}
+	want := [][]int{{1, 2, 3}, {4, 5, 6}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("clusters = %v, want %v", got, want)
+	}
+}